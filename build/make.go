@@ -168,7 +168,8 @@ func mirrorDir(src, dst string) error {
 		if err != nil {
 			return err
 		}
-		if fi.IsDir() {
+		// Go sources, like the one embedding the default theme, are compiled into the binary
+		if fi.IsDir() || filepath.Ext(path) == ".go" {
 			return nil
 		}
 		suffix, err := filepath.Rel(src, path)
@@ -198,7 +199,8 @@ func executeCommand(command string, arg ...string) (string, error) {
 }
 
 func compileGoPackage(packageName string) {
-	runProcess("go", "build", "-o", getGaugeExecutablePath(htmlReport))
+	ldflags := fmt.Sprintf("-X github.com/getgauge/html-report/theme.PluginVersion=%s", getPluginVersion())
+	runProcess("go", "build", "-ldflags", ldflags, "-o", getGaugeExecutablePath(htmlReport))
 }
 
 func getGaugeExecutablePath(file string) string {
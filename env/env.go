@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/getgauge/html-report/logger"
 
@@ -27,6 +28,28 @@ const (
 	pluginKillTimeout           = "plugin_kill_timeout"
 	gaugeMinifyReports          = "gauge_minify_reports"
 	gaugeMaxMessageSize         = "gauge_max_message_size"
+	reportsRetentionCount       = "html_report_retention_count"
+	reportsRetentionPeriod      = "html_report_retention_period"
+	durationFormat              = "html_report_duration_format"
+	colorScheme                 = "html_report_color_scheme"
+	locale                      = "html_report_locale"
+	timezone                    = "html_report_timezone"
+	metadataFile                = "html_report_metadata_file"
+	issueLinks                  = "html_report_issue_links"
+	sourceLink                  = "html_report_source_link"
+	ownersFile                  = "html_report_owners_file"
+	knownIssuesFile             = "html_report_known_issues_file"
+	specsOrder                  = "html_report_specs_order"
+	scenariosOrder              = "html_report_scenarios_order"
+	metadataPrefix              = "html_report_metadata_"
+)
+
+// The names of the time-stamped reports generated when overwrite_reports is false, and of the link to the latest
+// of them, in the reports directory.
+const (
+	TimeStampedReportFormat = "2006-01-02_15.04.05"
+	LatestReportLink        = "latest"
+	LatestReportRedirect    = "latest.html"
 )
 
 func GetCurrentExecutableDir() (string, string) {
@@ -122,3 +145,105 @@ var PluginKillTimeout = func() int {
 	}
 	return v / 1000
 }
+
+// ReportsRetentionCount returns the number of time-stamped reports to keep, 0 if unlimited
+func ReportsRetentionCount() int {
+	v, err := strconv.Atoi(os.Getenv(reportsRetentionCount))
+	if err != nil || v < 0 {
+		return 0
+	}
+	return v
+}
+
+// ReportsRetentionPeriod returns the maximum age of time-stamped reports to keep, 0 if unlimited.
+// Accepts go durations (e.g. 36h) as well as a number of days (e.g. 7d).
+func ReportsRetentionPeriod() time.Duration {
+	e := strings.TrimSpace(os.Getenv(reportsRetentionPeriod))
+	if e == "" {
+		return 0
+	}
+	if days, found := strings.CutSuffix(e, "d"); found {
+		d, err := strconv.Atoi(days)
+		if err != nil || d < 0 {
+			logger.Warnf("Invalid value for %s: %s", reportsRetentionPeriod, e)
+			return 0
+		}
+		return time.Duration(d) * 24 * time.Hour
+	}
+	d, err := time.ParseDuration(e)
+	if err != nil || d < 0 {
+		logger.Warnf("Invalid value for %s: %s", reportsRetentionPeriod, e)
+		return 0
+	}
+	return d
+}
+
+// ColorScheme returns the color scheme the report opens with, empty if not set
+func ColorScheme() string {
+	return strings.ToLower(strings.TrimSpace(os.Getenv(colorScheme)))
+}
+
+// Locale returns the locale the report is written and formatted for, e.g. de or ja-JP, empty if not set
+func Locale() string {
+	return strings.TrimSpace(os.Getenv(locale))
+}
+
+// Timezone returns the IANA time zone the timestamps of the report are shown in, e.g. Europe/Berlin, empty if not set
+func Timezone() string {
+	return strings.TrimSpace(os.Getenv(timezone))
+}
+
+// IssueLinks returns the patterns linked to the issue tracker, as `pattern => url` entries, empty if not set
+func IssueLinks() string {
+	return strings.TrimSpace(os.Getenv(issueLinks))
+}
+
+// SourceLink returns the URL template specs, scenarios and steps are linked to, empty if not set
+func SourceLink() string {
+	return strings.TrimSpace(os.Getenv(sourceLink))
+}
+
+// OwnersFile returns the path of the file mapping spec paths and tags to their owners, empty if not set
+func OwnersFile() string {
+	return strings.TrimSpace(os.Getenv(ownersFile))
+}
+
+// KnownIssuesFile returns the path of the JSON file listing the known issues of the suite, empty if not set
+func KnownIssuesFile() string {
+	return strings.TrimSpace(os.Getenv(knownIssuesFile))
+}
+
+// MetadataFile returns the path of the JSON file with the metadata of the report, empty if not set
+func MetadataFile() string {
+	return strings.TrimSpace(os.Getenv(metadataFile))
+}
+
+// MetadataProperties returns the metadata of the report set as environment properties prefixed by
+// html_report_metadata_, keyed by the rest of their name with underscores replaced by spaces.
+func MetadataProperties() map[string]string {
+	metadata := make(map[string]string)
+	for _, e := range os.Environ() {
+		name, value, _ := strings.Cut(e, "=")
+		key, found := strings.CutPrefix(name, metadataPrefix)
+		if !found || name == metadataFile || key == "" || strings.TrimSpace(value) == "" {
+			continue
+		}
+		metadata[strings.ReplaceAll(key, "_", " ")] = strings.TrimSpace(value)
+	}
+	return metadata
+}
+
+// SpecsOrder returns the order in which the specs are listed, empty if not set
+func SpecsOrder() string {
+	return strings.ToLower(strings.TrimSpace(os.Getenv(specsOrder)))
+}
+
+// ScenariosOrder returns the order in which the scenarios of a spec are shown, empty if not set
+func ScenariosOrder() string {
+	return strings.ToLower(strings.TrimSpace(os.Getenv(scenariosOrder)))
+}
+
+// DurationFormat returns the format in which execution times are rendered, empty if not set
+func DurationFormat() string {
+	return strings.ToLower(strings.TrimSpace(os.Getenv(durationFormat)))
+}
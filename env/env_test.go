@@ -1,6 +1,9 @@
 package env
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestMaxRecvMsgSize(t *testing.T) {
 	t.Run("empty value should return default", func(t *testing.T) {
@@ -27,3 +30,46 @@ func TestMaxRecvMsgSize(t *testing.T) {
 		}
 	})
 }
+
+func TestReportsRetentionPeriod(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"36h", 36 * time.Hour},
+		{"7d", 7 * 24 * time.Hour},
+		{"abcd", 0},
+		{"-1h", 0},
+	}
+	for _, tt := range tests {
+		t.Setenv(reportsRetentionPeriod, tt.value)
+		if got := ReportsRetentionPeriod(); got != tt.want {
+			t.Errorf("%q: expected %s, got %s", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestReportsRetentionCount(t *testing.T) {
+	t.Setenv(reportsRetentionCount, "")
+	if v := ReportsRetentionCount(); v != 0 {
+		t.Errorf("Expected 0, got %d", v)
+	}
+	t.Setenv(reportsRetentionCount, "10")
+	if v := ReportsRetentionCount(); v != 10 {
+		t.Errorf("Expected 10, got %d", v)
+	}
+}
+
+func TestMetadataProperties(t *testing.T) {
+	t.Setenv(metadataPrefix+"build_number", " 1024 ")
+	t.Setenv(metadataPrefix+"branch", "main")
+	t.Setenv(metadataPrefix+"empty", "")
+	t.Setenv(metadataFile, "metadata.json")
+
+	got := MetadataProperties()
+
+	if len(got) != 2 || got["build number"] != "1024" || got["branch"] != "main" {
+		t.Errorf("Unexpected metadata %v", got)
+	}
+}
@@ -0,0 +1,267 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"golang.org/x/net/html"
+)
+
+var specialParamsSpecRes = &gm.ProtoSpecResult{
+	ExecutionTime: 211316,
+	ProtoSpec: &gm.ProtoSpec{
+		SpecHeading: "Special parameters",
+		FileName:    "special_parameters.spec",
+		Items: []*gm.ProtoItem{
+			newScenarioItem(&gm.ProtoScenario{
+				ScenarioHeading: "Files and multiline strings",
+				ExecutionStatus: gm.ExecutionStatus_PASSED,
+				ScenarioItems: []*gm.ProtoItem{
+					{ItemType: gm.ProtoItem_Step, Step: protoStepWithSpecialParams},
+					{ItemType: gm.ProtoItem_Step, Step: protoStepWithMultilineString},
+				},
+			}),
+		},
+	},
+}
+
+// TestGeneratedReportIsAccessible checks the pages of the default theme against the parts of WCAG 2.1
+// that can be verified on the markup: every control is reachable by keyboard and named for screen readers.
+func TestGeneratedReportIsAccessible(t *testing.T) {
+	withScreenshots := newProtoSuiteRes(true, 1, 1, 60, nil, nil, passSpecRes1, failSpecResWithStepFailure, skippedSpecRes, specialParamsSpecRes)
+	withScreenshots.PreHookScreenshotFiles = []string{"pre-hook-screenshot.png"}
+	results := map[string]*SuiteResult{
+		"sample":               sampleSuiteResult(),
+		"special parameters":   ToSuiteResult("", withScreenshots),
+		"before suite failure": ToSuiteResult("", suiteResWithBeforeSuiteFailure),
+	}
+	for name, res := range results {
+		t.Run(name, func(t *testing.T) {
+			reportDir := t.TempDir()
+			if err := GenerateReports(res, reportDir, templateBasePath, false); err != nil {
+				t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+			}
+			pages, err := filepath.Glob(filepath.Join(reportDir, "*.html"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range pages {
+				f, err := os.Open(p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				doc, err := html.Parse(f)
+				f.Close()
+				if err != nil {
+					t.Fatalf("Error parsing %s: %s", p, err.Error())
+				}
+				for _, problem := range accessibilityProblems(doc) {
+					t.Errorf("%s: %s", filepath.Base(p), problem)
+				}
+			}
+		})
+	}
+}
+
+func TestAccessibilityProblemsAreReported(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body>
+		<div role="button">Filter</div>
+		<img src="a.png">
+		<input type="text">
+		<ul><a href="a.html"><li>A</li></a></ul>
+		<span id="dup"></span><span id="dup"></span>
+		<svg><path /></svg>
+		<div role="dialog"></div>
+	</body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := accessibilityProblems(doc)
+
+	want := []string{
+		"html element has no lang attribute",
+		"div with role button is not focusable",
+		"img has no alt attribute",
+		"input is not labelled",
+		"ul contains a",
+		"svg has no role img",
+		"svg has no accessible name",
+		"dialog has no accessible name",
+		"id dup is not unique",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected problems %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected problem %q, got %q", want[i], got[i])
+		}
+	}
+}
+
+func accessibilityProblems(doc *html.Node) []string {
+	var problems []string
+	ids := map[string]int{}
+	labelled := map[string]bool{}
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			if id := attr(n, "id"); id != "" {
+				ids[id]++
+			}
+			if n.Data == "label" && attr(n, "for") != "" {
+				labelled[attr(n, "for")] = true
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(doc)
+
+	var check func(n *html.Node, inLabel bool)
+	check = func(n *html.Node, inLabel bool) {
+		if n.Type == html.ElementNode {
+			problems = append(problems, elementProblems(n, inLabel, ids, labelled)...)
+			inLabel = inLabel || n.Data == "label"
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			check(c, inLabel)
+		}
+	}
+	check(doc, false)
+
+	for id, count := range ids {
+		if count > 1 {
+			problems = append(problems, fmt.Sprintf("id %s is not unique", id))
+		}
+	}
+	return problems
+}
+
+func elementProblems(n *html.Node, inLabel bool, ids map[string]int, labelled map[string]bool) []string {
+	var problems []string
+	for _, ref := range strings.Fields(attr(n, "aria-labelledby")) {
+		if ids[ref] == 0 {
+			problems = append(problems, fmt.Sprintf("%s is labelled by missing id %s", n.Data, ref))
+		}
+	}
+	switch n.Data {
+	case "html":
+		if attr(n, "lang") == "" {
+			problems = append(problems, "html element has no lang attribute")
+		}
+	case "img":
+		if _, ok := attrValue(n, "alt"); !ok {
+			problems = append(problems, "img has no alt attribute")
+		}
+	case "input", "select", "textarea":
+		if attr(n, "type") != "hidden" && !inLabel && !labelled[attr(n, "id")] && accessibleName(n, false) == "" {
+			problems = append(problems, fmt.Sprintf("%s is not labelled", n.Data))
+		}
+	case "a", "button":
+		if (n.Data == "button" || attr(n, "href") != "") && accessibleName(n, true) == "" {
+			problems = append(problems, fmt.Sprintf("%s has no accessible name", n.Data))
+		}
+	case "ul", "ol":
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && c.Data != "li" && c.Data != "script" && c.Data != "template" {
+				problems = append(problems, fmt.Sprintf("%s contains %s", n.Data, c.Data))
+			}
+		}
+	case "svg":
+		if attr(n, "aria-hidden") == "true" {
+			break
+		}
+		if attr(n, "role") != "img" {
+			problems = append(problems, "svg has no role img")
+		}
+		if accessibleName(n, false) == "" && !hasChild(n, "title") {
+			problems = append(problems, "svg has no accessible name")
+		}
+	}
+	switch attr(n, "role") {
+	case "button":
+		if n.Data != "button" && attr(n, "tabindex") == "" {
+			problems = append(problems, fmt.Sprintf("%s with role button is not focusable", n.Data))
+		}
+		if accessibleName(n, true) == "" {
+			problems = append(problems, fmt.Sprintf("%s with role button has no accessible name", n.Data))
+		}
+	case "dialog":
+		if accessibleName(n, false) == "" {
+			problems = append(problems, "dialog has no accessible name")
+		}
+	}
+	return problems
+}
+
+// accessibleName approximates the name a screen reader announces for an element:
+// its aria-label, labelledby or title, or, for controls named by their content, the text and image alternatives within.
+func accessibleName(n *html.Node, fromContent bool) string {
+	if v := strings.TrimSpace(attr(n, "aria-label")); v != "" {
+		return v
+	}
+	if attr(n, "aria-labelledby") != "" {
+		return attr(n, "aria-labelledby")
+	}
+	if v := strings.TrimSpace(attr(n, "title")); v != "" {
+		return v
+	}
+	if !fromContent {
+		return ""
+	}
+	var text strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			text.WriteString(n.Data)
+		}
+		if n.Type == html.ElementNode {
+			if attr(n, "aria-hidden") == "true" {
+				return
+			}
+			if n.Data == "img" {
+				text.WriteString(attr(n, "alt"))
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.TrimSpace(text.String())
+}
+
+func hasChild(n *html.Node, name string) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == name {
+			return true
+		}
+	}
+	return false
+}
+
+func attr(n *html.Node, key string) string {
+	v, _ := attrValue(n, key)
+	return v
+}
+
+func attrValue(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,215 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/html-report/env"
+	"github.com/getgauge/html-report/logger"
+)
+
+const (
+	reportCacheFile    = ".html-report-cache.json"
+	reportCacheVersion = "2"
+	specPageTemplate   = "specPage"
+)
+
+// reportCache records a hash of the input each spec page was rendered from, keyed by the page path relative to
+// the report directory. It lets a regeneration reuse pages whose input has not changed.
+type reportCache struct {
+	Version string            `json:"Version"`
+	Theme   string            `json:"Theme"`
+	Pages   map[string]string `json:"Pages"`
+}
+
+func newReportCache(themeHash string) *reportCache {
+	return &reportCache{Version: reportCacheVersion, Theme: themeHash, Pages: make(map[string]string)}
+}
+
+func readReportCache(reportDir string) *reportCache {
+	b, err := os.ReadFile(filepath.Join(reportDir, reportCacheFile))
+	if err != nil {
+		return nil
+	}
+	var c reportCache
+	if err := json.Unmarshal(b, &c); err != nil || c.Version != reportCacheVersion {
+		logger.Debugf("Ignoring stale report cache in %s", reportDir)
+		return nil
+	}
+	return &c
+}
+
+func (c *reportCache) write(reportDir string) error {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(reportDir, reportCacheFile), b, common.NewFilePermissions)
+}
+
+// themeHash fingerprints everything besides the spec data that changes the rendered spec pages: the templates
+// a spec page is rendered with, the translations of the theme and the environment switches read while rendering.
+// It is computed once the templates are parsed, so that views the spec pages do not use leave them cached.
+func themeHash() string {
+	h := sha256.New()
+	for _, t := range specPageTemplates(parsedTemplates) {
+		_, _ = io.WriteString(h, t.Name())
+		_, _ = io.WriteString(h, t.Tree.Root.String())
+	}
+	_, _ = io.WriteString(h, os.Getenv("screenshot_on_failure"))
+	_, _ = io.WriteString(h, env.DurationFormat())
+	_, _ = io.WriteString(h, env.ColorScheme())
+	_, _ = io.WriteString(h, env.Locale())
+	_, _ = io.WriteString(h, env.Timezone())
+	_, _ = io.WriteString(h, env.IssueLinks())
+	_, _ = io.WriteString(h, env.SourceLink())
+	_, _ = io.WriteString(h, env.SpecsOrder())
+	_, _ = io.WriteString(h, env.ScenariosOrder())
+	_ = json.NewEncoder(h).Encode(translations)
+	if env.ShouldMinifyReports() {
+		_, _ = io.WriteString(h, "minify")
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// specPageTemplates returns the spec page template and those it includes, directly or not, sorted by name.
+func specPageTemplates(templates *template.Template) []*template.Template {
+	if templates == nil {
+		return nil
+	}
+	seen := make(map[string]*template.Template)
+	var visit func(n parse.Node)
+	include := func(name string) {
+		t := templates.Lookup(name)
+		if _, ok := seen[name]; ok || t == nil || t.Tree == nil {
+			return
+		}
+		seen[name] = t
+		visit(t.Tree.Root)
+	}
+	visit = func(n parse.Node) {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Nodes {
+				visit(c)
+			}
+		case *parse.IfNode:
+			visit(n.List)
+			visit(n.ElseList)
+		case *parse.RangeNode:
+			visit(n.List)
+			visit(n.ElseList)
+		case *parse.WithNode:
+			visit(n.List)
+			visit(n.ElseList)
+		case *parse.TemplateNode:
+			include(n.Name)
+		}
+	}
+	include(specPageTemplate)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	list := make([]*template.Template, 0, len(names))
+	for _, name := range names {
+		list = append(list, seen[name])
+	}
+	return list
+}
+
+// specPageHash hashes the data a spec page is rendered from: the spec itself, the suite overview, the sidebar and
+// the suite hook failures. The overview holds the time, the execution time and the metadata of the run, so every
+// page of a new run is rendered again rather than showing those of the run it was first rendered in.
+func specPageHash(suiteRes *SuiteResult, specRes *spec) (string, error) {
+	o := toOverview(suiteRes, specRes.FileName)
+	sb := toSidebar(suiteRes, specRes.FileName)
+	h := sha256.New()
+	enc := json.NewEncoder(h)
+	for _, v := range []interface{}{
+		o,
+		sb,
+		suiteRes.BeforeSuiteHookFailure,
+		suiteRes.AfterSuiteHookFailure,
+		specRes,
+	} {
+		if err := enc.Encode(v); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// incrementalBuild reuses spec pages of a previously generated report whose input hash is unchanged.
+type incrementalBuild struct {
+	previousDir string
+	previous    *reportCache
+	current     *reportCache
+}
+
+func newIncrementalBuild(previousDir string) *incrementalBuild {
+	return &incrementalBuild{previousDir: previousDir, previous: readReportCache(previousDir), current: newReportCache("")}
+}
+
+// start records the theme hash of the pages about to be rendered. The previous pages are only reused if they were
+// rendered with the same one.
+func (b *incrementalBuild) start(themeHash string) {
+	if b == nil {
+		return
+	}
+	b.current = newReportCache(themeHash)
+	if b.previous != nil && (themeHash == "" || b.previous.Theme != themeHash) {
+		b.previous = nil
+	}
+}
+
+// reuse records the hash of the page and links the previously rendered page into place if it is unchanged.
+// It returns false if the page has to be rendered.
+func (b *incrementalBuild) reuse(suiteRes *SuiteResult, specRes *spec, relPath, dest string) bool {
+	if b == nil {
+		return false
+	}
+	h, err := specPageHash(suiteRes, specRes)
+	if err != nil {
+		logger.Debugf("Unable to hash %s: %s", relPath, err.Error())
+		return false
+	}
+	b.current.Pages[relPath] = h
+	if b.previous == nil || b.previous.Pages[relPath] != h {
+		return false
+	}
+	if err := linkOrCopy(filepath.Join(b.previousDir, relPath), dest); err != nil {
+		logger.Debugf("Unable to reuse %s: %s", relPath, err.Error())
+		return false
+	}
+	return true
+}
+
+func linkOrCopy(src, dest string) error {
+	_ = os.Remove(dest)
+	if err := os.Link(src, dest); err == nil {
+		return nil
+	}
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dest, b, common.NewFilePermissions)
+}
@@ -0,0 +1,124 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+)
+
+func sameFile(t *testing.T, a, b string) bool {
+	fa, err := os.Stat(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fb, err := os.Stat(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return os.SameFile(fa, fb)
+}
+
+func TestIncrementalBuildReusesUnchangedPages(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	page := "passing_specification_1.html"
+
+	err := generateReports(ToSuiteResult("", suiteRes3), first, templateBasePath, false, newIncrementalBuild(first))
+	if err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+	if readReportCache(first) == nil {
+		t.Fatalf("Expected %s to be written", reportCacheFile)
+	}
+	err = generateReports(ToSuiteResult("", suiteRes3), second, templateBasePath, false, newIncrementalBuild(first))
+	if err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+
+	if !sameFile(t, filepath.Join(first, page), filepath.Join(second, page)) {
+		t.Errorf("Expected unchanged page %s to be reused", page)
+	}
+}
+
+func TestIncrementalBuildRendersChangedPages(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	page := "passing_specification_1.html"
+
+	err := generateReports(ToSuiteResult("", suiteRes3), first, templateBasePath, false, newIncrementalBuild(first))
+	if err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+	res := ToSuiteResult("", suiteRes3)
+	res.ProjectName = "Renamed Project"
+	err = generateReports(res, second, templateBasePath, false, newIncrementalBuild(first))
+	if err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+
+	if sameFile(t, filepath.Join(first, page), filepath.Join(second, page)) {
+		t.Errorf("Expected page %s to be rendered again", page)
+	}
+}
+
+func TestIncrementalBuildRendersEveryPageOfANewRun(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+
+	err := generateReports(ToSuiteResult("", suiteRes3), first, templateBasePath, false, newIncrementalBuild(first))
+	if err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+	res := ToSuiteResult("", suiteRes3)
+	res.Timestamp = "Jul 14, 2016 at 9:12am"
+	res.TimestampISO = "2016-07-14T09:12:00Z"
+	res.Metadata = map[string]string{"build_number": "1024"}
+	err = generateReports(res, second, templateBasePath, false, newIncrementalBuild(first))
+	if err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+
+	for _, s := range res.SpecResults {
+		page := toHTMLFileName(s.FileName, projectRoot)
+		if sameFile(t, filepath.Join(first, page), filepath.Join(second, page)) {
+			t.Errorf("Expected page %s to be rendered again with the time and metadata of the new run", page)
+		}
+	}
+}
+
+func TestThemeHashIgnoresTemplatesSpecPagesDoNotUse(t *testing.T) {
+	readTemplates(templateBasePath)
+	defer readTemplates(templateBasePath)
+	before := themeHash()
+
+	template.Must(parsedTemplates.New(tagsTemplate).Parse(`changed`))
+	if got := themeHash(); got != before {
+		t.Errorf("Expected the theme hash to ignore the tags page")
+	}
+	template.Must(parsedTemplates.New("bodyFooterTag").Parse(`changed`))
+	if got := themeHash(); got == before {
+		t.Errorf("Expected the theme hash to change with the footer of the spec pages")
+	}
+}
+
+func TestPublishReportRemovesPagesOfDeletedSpecs(t *testing.T) {
+	root := t.TempDir()
+	reportDir := filepath.Join(root, "html-report")
+	writeFileOrFail(t, filepath.Join(reportDir, "specs", "deleted.html"), "stale")
+	staging, err := prepareStagingDir(reportDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFileOrFail(t, filepath.Join(staging, "index.html"), "new")
+
+	if err := publishReport(staging, reportDir); err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+
+	if _, err := os.Stat(filepath.Join(reportDir, "specs", "deleted.html")); !os.IsNotExist(err) {
+		t.Errorf("Expected page of deleted spec to be removed")
+	}
+}
@@ -0,0 +1,30 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"github.com/getgauge/html-report/logger"
+)
+
+const autoColorScheme = "auto"
+
+// colorSchemes are the variants of the default theme. The automatic one follows the preferences of the
+// operating system of the reader, who can pick another one in the report.
+var colorSchemes = map[string]bool{autoColorScheme: true, "light": true, "dark": true, "high-contrast": true}
+
+var colorScheme = autoColorScheme
+
+func setColorScheme(scheme string) {
+	switch {
+	case scheme == "":
+		colorScheme = autoColorScheme
+	case colorSchemes[scheme]:
+		colorScheme = scheme
+	default:
+		logger.Warnf("Unknown color scheme %s, using %s", scheme, autoColorScheme)
+		colorScheme = autoColorScheme
+	}
+}
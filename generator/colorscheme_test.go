@@ -0,0 +1,30 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestColorSchemeIsRenderedOnHTMLElement(t *testing.T) {
+	readTemplates(templateBasePath)
+	setColorScheme("high-contrast")
+	defer setColorScheme("")
+	buf := new(bytes.Buffer)
+
+	execTemplate("htmlPageStartTag", buf, toOverview(suiteRes1, ""))
+
+	if want := `<html lang="en" data-color-scheme="high-contrast">`; !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected %s in\n%s", want, buf.String())
+	}
+}
+
+func TestUnknownColorSchemeFallsBackToAuto(t *testing.T) {
+	setColorScheme("sepia")
+	checkEqual(t, "", autoColorScheme, colorScheme)
+}
@@ -0,0 +1,73 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getgauge/html-report/logger"
+)
+
+const (
+	humanDurationFormat = "human"
+	clockDurationFormat = "clock"
+)
+
+var durationFormat = humanDurationFormat
+
+// duration is an execution time in milliseconds, as reported by gauge. It renders itself in the configured format.
+type duration int64
+
+func (d duration) String() string {
+	if durationFormat == clockDurationFormat {
+		return d.clock()
+	}
+	return d.human()
+}
+
+// Milliseconds returns the duration as a number, e.g. for sorting in the browser.
+func (d duration) Milliseconds() int64 {
+	return int64(d)
+}
+
+// clock renders the duration as hh:mm:ss.mmm. Hours do not wrap around after a day.
+func (d duration) clock() string {
+	ms := int64(d)
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// human renders the duration as e.g. 1h 3m 2.4s, or 300ms if it is under a second.
+func (d duration) human() string {
+	ms := int64(d)
+	if ms < 1000 {
+		return fmt.Sprintf("%dms", ms)
+	}
+	parts := make([]string, 0, 3)
+	if h := ms / 3600000; h > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", h))
+	}
+	if m := ms / 60000 % 60; m > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", m))
+	}
+	if s := ms % 60000; s > 0 {
+		parts = append(parts, strconv.FormatFloat(float64(s)/1000, 'f', -1, 64)+"s")
+	}
+	return strings.Join(parts, " ")
+}
+
+func setDurationFormat(format string) {
+	switch format {
+	case "":
+		durationFormat = humanDurationFormat
+	case humanDurationFormat, clockDurationFormat:
+		durationFormat = format
+	default:
+		logger.Warnf("Unknown duration format %s, using %s", format, humanDurationFormat)
+		durationFormat = humanDurationFormat
+	}
+}
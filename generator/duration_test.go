@@ -0,0 +1,45 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"testing"
+)
+
+var durationTests = []struct {
+	ms    duration
+	human string
+	clock string
+}{
+	{0, "0ms", "00:00:00.000"},
+	{300, "300ms", "00:00:00.300"},
+	{2400, "2.4s", "00:00:02.400"},
+	{211316, "3m 31.316s", "00:03:31.316"},
+	{120000, "2m", "00:02:00.000"},
+	{3782400, "1h 3m 2.4s", "01:03:02.400"},
+	{3600005, "1h 0.005s", "01:00:00.005"},
+	{90000000, "25h", "25:00:00.000"},
+}
+
+func TestDurationInHumanFormat(t *testing.T) {
+	setDurationFormat("")
+	for _, test := range durationTests {
+		checkEqual(t, "", test.human, test.ms.String())
+	}
+}
+
+func TestDurationInClockFormat(t *testing.T) {
+	setDurationFormat(clockDurationFormat)
+	defer setDurationFormat("")
+	for _, test := range durationTests {
+		checkEqual(t, "", test.clock, test.ms.String())
+	}
+}
+
+func TestUnknownDurationFormatFallsBackToHuman(t *testing.T) {
+	setDurationFormat("fancy")
+	checkEqual(t, "", humanDurationFormat, durationFormat)
+}
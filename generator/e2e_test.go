@@ -52,6 +52,9 @@ func TestEndToEndHTMLGeneration(t *testing.T) {
 	}
 
 	verifyExpectedFiles(t, "simpleSuiteRes", reportDir, expectedFiles)
+	if !helper.FileExists(filepath.Join(reportDir, performancePage)) {
+		t.Errorf("Expected %s to be generated", performancePage)
+	}
 	cleanUp(t, reportDir)
 }
 
@@ -116,7 +119,10 @@ func TestEndToEndHTMLGenerationForCustomTheme(t *testing.T) {
 		t.Errorf("Expected error to be nil. Got: %s", err.Error())
 	}
 
-	verifyExpectedFiles(t, "simpleSuiteRes", reportDir, expectedFiles)
+	verifyExpectedFiles(t, "customThemeSuiteRes", reportDir, expectedFiles)
+	if helper.FileExists(filepath.Join(reportDir, performancePage)) {
+		t.Errorf("Expected %s not to be generated for a theme without a performance page", performancePage)
+	}
 	cleanUp(t, reportDir)
 }
 
@@ -0,0 +1,86 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QualityGates are the thresholds a suite result has to meet, e.g. for a CI job to pass. The zero value of a
+// threshold disables it, except for MaxFailedScenarios which is disabled by a negative value.
+type QualityGates struct {
+	MinSuccessRate     float64
+	MaxFailedScenarios int
+	// CriticalTag tags the specs and scenarios which must not fail
+	CriticalTag string
+	MaxDuration time.Duration
+}
+
+// Violations returns a description of every threshold the suite result does not meet. Known issues are not
+// counted as failed scenarios, nor as failures of critical specs, and the success rate leaves out the specs which
+// failed because of known issues only.
+func (g *QualityGates) Violations(res *SuiteResult) []string {
+	var violations []string
+	if g.MinSuccessRate > 0 && float64(res.SuccessRate) < g.MinSuccessRate {
+		violations = append(violations, fmt.Sprintf("Success rate %v%% is below the minimum of %v%%", res.SuccessRate, g.MinSuccessRate))
+	}
+	if g.MaxFailedScenarios >= 0 && res.FailedScenarioCount > g.MaxFailedScenarios {
+		violations = append(violations, fmt.Sprintf("%d scenarios failed, more than the maximum of %d", res.FailedScenarioCount, g.MaxFailedScenarios))
+	}
+	if g.CriticalTag != "" {
+		if failures := criticalFailures(res, g.CriticalTag); len(failures) > 0 {
+			violations = append(violations, fmt.Sprintf("%d specifications or scenarios tagged %s failed: %s", len(failures), g.CriticalTag, strings.Join(failures, ", ")))
+		}
+	}
+	if limit := duration(g.MaxDuration.Milliseconds()); limit > 0 && res.ExecutionTime > limit {
+		violations = append(violations, fmt.Sprintf("Suite took %s, longer than the maximum of %s", res.ExecutionTime.human(), limit.human()))
+	}
+	return violations
+}
+
+// criticalFailures returns the failed scenarios carrying the tag, themselves or through their spec, and the
+// specs carrying it which failed without a failed scenario, e.g. in a hook. Every failed row of a table driven
+// scenario counts as a failure.
+func criticalFailures(res *SuiteResult, tag string) []string {
+	var failures []string
+	for _, s := range res.SpecResults {
+		specTagged := slices.Contains(s.Tags, tag)
+		if specTagged && s.ExecutionStatus == fail && !s.HasOnlyKnownIssues && s.FailedScenarioCount == 0 {
+			failures = append(failures, s.SpecHeading)
+			continue
+		}
+		for _, scn := range s.Scenarios {
+			if scn.ExecutionStatus != fail || scn.KnownIssue != nil {
+				continue
+			}
+			if specTagged || slices.Contains(scn.Tags, tag) {
+				failures = append(failures, failureName(s, scn))
+			}
+		}
+	}
+	return failures
+}
+
+// failureName names a failed scenario after its spec, with the rows of the data tables it ran for, e.g.
+// "Checkout > Pay (row 2)".
+func failureName(s *spec, scn *scenario) string {
+	var rows []string
+	if s.Datatable != nil && scn.TableRowIndex >= 0 {
+		rows = append(rows, strconv.Itoa(scn.TableRowIndex+1))
+	}
+	if scn.IsScenarioTableDriven {
+		rows = append(rows, strconv.Itoa(scn.ScenarioTableRowIndex+1))
+	}
+	name := s.SpecHeading + " > " + scn.Heading
+	if len(rows) > 0 {
+		name += " (row " + strings.Join(rows, ", ") + ")"
+	}
+	return name
+}
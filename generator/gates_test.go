@@ -0,0 +1,81 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"testing"
+	"time"
+)
+
+func gatesSuite() *SuiteResult {
+	res := knownIssuesSuite()
+	res.SuccessRate = 50
+	res.ExecutionTime = 90000
+	res.SpecResults[0].Tags = []string{"critical"}
+	res.SpecResults[2].Scenarios[0].Tags = []string{"critical"}
+	return res
+}
+
+func TestQualityGatesAreDisabledByDefault(t *testing.T) {
+	gates := &QualityGates{MaxFailedScenarios: -1}
+
+	if got := gates.Violations(gatesSuite()); len(got) != 0 {
+		t.Errorf("Expected no violations, got %v", got)
+	}
+}
+
+func TestQualityGatesReportEveryViolatedThreshold(t *testing.T) {
+	gates := &QualityGates{MinSuccessRate: 80, MaxFailedScenarios: 2, CriticalTag: "critical", MaxDuration: time.Minute}
+
+	got := gates.Violations(gatesSuite())
+
+	checkEqual(t, "", []string{
+		"Success rate 50% is below the minimum of 80%",
+		"3 scenarios failed, more than the maximum of 2",
+		"2 specifications or scenarios tagged critical failed: Checkout > Pay by voucher, Checkout > Pay by card",
+		"Suite took 1m 30s, longer than the maximum of 1m",
+	}, got)
+}
+
+func TestQualityGatesIgnoreKnownIssues(t *testing.T) {
+	res := gatesSuite()
+	markKnownIssues(res, []*knownIssueRule{{scenario: "Pay by voucher"}, {scenario: "Login"}}, "/project")
+	gates := &QualityGates{MaxFailedScenarios: 1, CriticalTag: "critical"}
+
+	got := gates.Violations(res)
+
+	checkEqual(t, "", []string{"1 specifications or scenarios tagged critical failed: Checkout > Pay by card"}, got)
+}
+
+func TestQualityGatesReportCriticalSpecsFailedWithoutScenarios(t *testing.T) {
+	res := &SuiteResult{SpecResults: []*spec{
+		{SpecHeading: "Setup", Tags: []string{"critical"}, ExecutionStatus: fail, BeforeSpecHookFailures: []*hookFailure{{ErrMsg: "no db"}}},
+		{SpecHeading: "Other", ExecutionStatus: fail, Scenarios: []*scenario{{Heading: "Other", ExecutionStatus: fail}}},
+	}}
+	gates := &QualityGates{MaxFailedScenarios: -1, CriticalTag: "critical"}
+
+	got := gates.Violations(res)
+
+	checkEqual(t, "", []string{"1 specifications or scenarios tagged critical failed: Setup"}, got)
+}
+
+func TestQualityGatesCountEveryFailedRowOfCriticalTableDrivenScenarios(t *testing.T) {
+	res := &SuiteResult{SpecResults: []*spec{
+		{SpecHeading: "Checkout", Tags: []string{"critical"}, ExecutionStatus: fail, FailedScenarioCount: 2, Datatable: &table{}, Scenarios: []*scenario{
+			{Heading: "Pay", ExecutionStatus: fail, TableRowIndex: 0},
+			{Heading: "Pay", ExecutionStatus: pass, TableRowIndex: 1},
+			{Heading: "Pay", ExecutionStatus: fail, TableRowIndex: 2},
+		}},
+		{SpecHeading: "Refund", Tags: []string{"critical"}, ExecutionStatus: fail, FailedScenarioCount: 1, Scenarios: []*scenario{
+			{Heading: "Refund", ExecutionStatus: fail, TableRowIndex: -1, IsScenarioTableDriven: true, ScenarioTableRowIndex: 1},
+		}},
+	}}
+	gates := &QualityGates{MaxFailedScenarios: -1, CriticalTag: "critical"}
+
+	got := gates.Violations(res)
+
+	checkEqual(t, "", []string{"3 specifications or scenarios tagged critical failed: Checkout > Pay (row 1), Checkout > Pay (row 3), Refund > Refund (row 2)"}, got)
+}
@@ -33,6 +33,7 @@ type summary struct {
 	Failed  int
 	Passed  int
 	Skipped int
+	Known   int
 }
 
 type overview struct {
@@ -40,7 +41,7 @@ type overview struct {
 	Env                     string
 	Tags                    string
 	SuccessRate             float32
-	ExecutionTime           string
+	ExecutionTime           duration
 	Timestamp               string
 	Summary                 *summary
 	ScenarioSummary         *summary
@@ -51,28 +52,42 @@ type overview struct {
 	PostHookScreenshots     []string
 	PreHookScreenshotFiles  []string
 	PostHookScreenshotFiles []string
+	HasTimeline             bool
+	ColorScheme             string
+	TimestampISO            string
+	Metadata                []metadataEntry
+	HasTags                 bool
+	HasScenarios            bool
 }
 
 type specsMeta struct {
 	SpecName      string
-	ExecutionTime string
+	ExecutionTime duration
 	Failed        bool
 	Skipped       bool
+	Known         bool
 	Tags          []string
 	ReportFile    string
+	// ExecutionIndex and SourceIndex are the positions of the spec in the order of execution and of the spec files
+	ExecutionIndex int
+	SourceIndex    int
 }
 
 type sidebar struct {
 	IsBeforeHookFailure bool
 	Specs               []*specsMeta
+	Order               string
+	// Tree groups the specs by folder, for the reports with an index page per folder
+	Tree *specsDir
 }
 
 type specHeader struct {
 	SpecName      string
-	ExecutionTime string
+	ExecutionTime duration
 	FileName      string
 	Tags          []string
 	Summary       *summary
+	Source        *source
 }
 
 type errorType string
@@ -84,33 +99,46 @@ type buildError struct {
 	FileName   string
 	LineNumber int
 	Message    string
+	Source     *source
 }
 
 // SuiteResult holds the aggregated execution information for a run
 type SuiteResult struct {
-	ProjectName             string       `json:"ProjectName"`
-	Timestamp               string       `json:"Timestamp"`
-	SuccessRate             float32      `json:"SuccessRate"`
-	Environment             string       `json:"Environment"`
-	Tags                    string       `json:"Tags"`
-	ExecutionTime           int64        `json:"ExecutionTime"`
-	ExecutionStatus         status       `json:"ExecutionStatus"`
-	SpecResults             []*spec      `json:"SpecResults"`
-	BeforeSuiteHookFailure  *hookFailure `json:"BeforeSuiteHookFailure"`
-	AfterSuiteHookFailure   *hookFailure `json:"AfterSuiteHookFailure"`
-	PassedSpecsCount        int          `json:"PassedSpecsCount"`
-	FailedSpecsCount        int          `json:"FailedSpecsCount"`
-	SkippedSpecsCount       int          `json:"SkippedSpecsCount"`
-	PassedScenarioCount     int          `json:"PassedScenarioCount"`
-	FailedScenarioCount     int          `json:"FailedScenarioCount"`
-	SkippedScenarioCount    int          `json:"SkippedScenarioCount"`
-	BasePath                string       `json:"BasePath"`
-	PreHookMessages         []string     `json:"PreHookMessages"`
-	PostHookMessages        []string     `json:"PostHookMessages"`
-	PreHookScreenshotFiles  []string     `json:"PreHookScreenshotFiles"`
-	PostHookScreenshotFiles []string     `json:"PostHookScreenshotFiles"`
-	PreHookScreenshots      []string     `json:"PreHookScreenshots"`
-	PostHookScreenshots     []string     `json:"PostHookScreenshots"`
+	ProjectName             string            `json:"ProjectName"`
+	Timestamp               string            `json:"Timestamp"`
+	TimestampISO            string            `json:"TimestampISO"`
+	Metadata                map[string]string `json:"Metadata,omitempty"`
+	SuccessRate             float32           `json:"SuccessRate"`
+	Environment             string            `json:"Environment"`
+	Tags                    string            `json:"Tags"`
+	ExecutionTime           duration          `json:"ExecutionTime"`
+	ExecutionStatus         status            `json:"ExecutionStatus"`
+	SpecResults             []*spec           `json:"SpecResults"`
+	BeforeSuiteHookFailure  *hookFailure      `json:"BeforeSuiteHookFailure"`
+	AfterSuiteHookFailure   *hookFailure      `json:"AfterSuiteHookFailure"`
+	PassedSpecsCount        int               `json:"PassedSpecsCount"`
+	FailedSpecsCount        int               `json:"FailedSpecsCount"`
+	SkippedSpecsCount       int               `json:"SkippedSpecsCount"`
+	PassedScenarioCount     int               `json:"PassedScenarioCount"`
+	FailedScenarioCount     int               `json:"FailedScenarioCount"`
+	SkippedScenarioCount    int               `json:"SkippedScenarioCount"`
+	KnownIssueSpecsCount    int               `json:"KnownIssueSpecsCount"`
+	KnownIssueScenarioCount int               `json:"KnownIssueScenarioCount"`
+	BasePath                string            `json:"BasePath"`
+	PreHookMessages         []string          `json:"PreHookMessages"`
+	PostHookMessages        []string          `json:"PostHookMessages"`
+	PreHookScreenshotFiles  []string          `json:"PreHookScreenshotFiles"`
+	PostHookScreenshotFiles []string          `json:"PostHookScreenshotFiles"`
+	PreHookScreenshots      []string          `json:"PreHookScreenshots"`
+	PostHookScreenshots     []string          `json:"PostHookScreenshots"`
+	Timeline                *Timeline         `json:"-"`
+	// hasTagsPage is set while generating the report, for the pages to link to the tags page
+	hasTagsPage bool
+	// hasScenariosPage is set while generating the report, for the pages to link to the scenarios page
+	hasScenariosPage bool
+	// hasNestedSpecs is set while generating the report with an index page per folder of specs, for the sidebars to
+	// list the specs by folder
+	hasNestedSpecs bool
 }
 
 type spec struct {
@@ -121,7 +149,7 @@ type spec struct {
 	FileName                string         `json:"FileName"`
 	SpecFileName            string         `json:"SpecFileName"`
 	Tags                    []string       `json:"Tags"`
-	ExecutionTime           int64          `json:"ExecutionTime"`
+	ExecutionTime           duration       `json:"ExecutionTime"`
 	ExecutionStatus         status         `json:"ExecutionStatus"`
 	Scenarios               []*scenario    `json:"Scenarios"`
 	IsTableDriven           bool           `json:"IsTableDriven"`
@@ -131,6 +159,8 @@ type spec struct {
 	PassedScenarioCount     int            `json:"PassedScenarioCount"`
 	FailedScenarioCount     int            `json:"FailedScenarioCount"`
 	SkippedScenarioCount    int            `json:"SkippedScenarioCount"`
+	KnownIssueScenarioCount int            `json:"KnownIssueScenarioCount"`
+	HasOnlyKnownIssues      bool           `json:"HasOnlyKnownIssues"`
 	Errors                  []buildError   `json:"Errors"`
 	PreHookMessages         []string       `json:"PreHookMessages"`
 	PostHookMessages        []string       `json:"PostHookMessages"`
@@ -138,13 +168,15 @@ type spec struct {
 	PostHookScreenshotFiles []string       `json:"PostHookScreenshotFiles"`
 	PreHookScreenshots      []string       `json:"PreHookScreenshots"`
 	PostHookScreenshots     []string       `json:"PostHookScreenshots"`
+	Source                  *source        `json:"Source"`
+	Owners                  []string       `json:"Owners,omitempty"`
 }
 
 type scenario struct {
 	BasePath                  string       `json:"BasePath"`
 	Heading                   string       `json:"Heading"`
 	Tags                      []string     `json:"Tags"`
-	ExecutionTime             string       `json:"ExecutionTime"`
+	ExecutionTime             duration     `json:"ExecutionTime"`
 	ExecutionStatus           status       `json:"ExecutionStatus"`
 	Contexts                  []item       `json:"Contexts"`
 	Teardowns                 []item       `json:"Teardowns"`
@@ -165,6 +197,13 @@ type scenario struct {
 	PreHookScreenshots        []string     `json:"PreHookScreenshots"`
 	PostHookScreenshots       []string     `json:"PostHookScreenshots"`
 	RetriesCount              int          `json:"RetriesCount"`
+	IsRerun                   bool         `json:"IsRerun"`
+	PreviousExecutionStatus   status       `json:"PreviousExecutionStatus"`
+	Source                    *source      `json:"Source"`
+	Owners                    []string     `json:"Owners,omitempty"`
+	KnownIssue                *knownIssue  `json:"KnownIssue,omitempty"`
+	// ExecutionIndex is the position of the scenario in the order of execution
+	ExecutionIndex int `json:"ExecutionIndex"`
 }
 
 type step struct {
@@ -182,6 +221,7 @@ type step struct {
 	PostHookScreenshotFiles []string     `json:"PostHookScreenshotFiles"`
 	PreHookScreenshots      []string     `json:"PreHookScreenshots"`
 	PostHookScreenshots     []string     `json:"PostHookScreenshots"`
+	Source                  *source      `json:"Source"`
 }
 
 func (s *step) Kind() tokenKind {
@@ -195,7 +235,7 @@ type result struct {
 	FailureScreenshotFile string    `json:"ScreenshotFile"`
 	FailureScreenshot     string    `json:"Screenshot"`
 	ErrorMessage          string    `json:"ErrorMessage"`
-	ExecutionTime         string    `json:"ExecutionTime"`
+	ExecutionTime         duration  `json:"ExecutionTime"`
 	SkippedReason         string    `json:"SkippedReason"`
 	Messages              []string  `json:"Messages"`
 	ErrorType             errorType `json:"ErrorType"`
@@ -261,11 +301,23 @@ func (c *comment) Kind() tokenKind {
 }
 
 type SearchIndex struct {
-	Tags  map[string][]string `json:"Tags"`
-	Specs map[string][]string `json:"Specs"`
+	Tags   map[string][]string `json:"Tags"`
+	Specs  map[string][]string `json:"Specs"`
+	Owners map[string][]string `json:"Owners,omitempty"`
 }
 
-var htmlFiles = make([]string, 0)
+var (
+	// htmlFiles are the pages written while generating the report, to be minified. Pages are rendered concurrently,
+	// so they are added with addHTMLFile.
+	htmlFiles   = make([]string, 0)
+	htmlFilesMu sync.Mutex
+)
+
+func addHTMLFile(p string) {
+	htmlFilesMu.Lock()
+	defer htmlFilesMu.Unlock()
+	htmlFiles = append(htmlFiles, p)
+}
 
 func minifyHTMLFiles(htmlFilePaths []string, reportsDir string) {
 	m := minify.New()
@@ -330,6 +382,21 @@ const (
 var parsedTemplates *template.Template
 
 func readTemplates(themePath string) {
+	t, err := loadTheme(themePath)
+	if err != nil {
+		logger.Fatalf("Invalid theme %s: %s", themePath, err.Error())
+	}
+	parsedTemplates, err = parseTemplates(t)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	translations, err = t.Translations()
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+}
+
+func templateFuncs() template.FuncMap {
 	var encodeNewLine = func(s string) string {
 		return strings.ReplaceAll(s, "\n", "<br/>")
 	}
@@ -355,15 +422,26 @@ func readTemplates(themePath string) {
 		return os.Getenv("screenshot_on_failure") == "true"
 	}
 
-	var funcs = template.FuncMap{
+	return template.FuncMap{
 		"parseMarkdown":              parseMarkdown,
 		"sanitize":                   sanitizeHTML,
 		"escapeHTML":                 template.HTMLEscapeString,
+		"linkIssues":                 linkIssues,
+		"sourceLink":                 sourceLink,
 		"encodeNewLine":              encodeNewLine,
 		"containsParseErrors":        containsParseErrors,
 		"toSpecHeader":               toSpecHeader,
 		"toSidebar":                  toSidebar,
 		"toOverview":                 toOverview,
+		"toPerformance":              toPerformance,
+		"toTimeline":                 toTimeline,
+		"toTagStats":                 toTagStats,
+		"toOwnerFailures":            toOwnerFailures,
+		"tr":                         translate,
+		"formatNumber":               formatNumber,
+		"reportLanguage":             func() string { return reportLanguage },
+		"scenariosOrder":             func() string { return scenariosOrder },
+		"timezone":                   func() string { return timezone },
 		"toPath":                     func(elem ...string) string { return filepath.ToSlash(filepath.Clean(path.Join(elem...))) },
 		"stringContains":             strings.Contains,
 		"stringHasPrefix":            strings.HasPrefix,
@@ -379,15 +457,34 @@ func readTemplates(themePath string) {
 		"sum":                        sum,
 		"screenshotOfFailureEnabled": screenshotOfFailureEnabled,
 	}
+}
 
-	f, err := os.ReadFile(filepath.Join(getAbsThemePath(themePath), "views", "partials.tmpl"))
+// parseTemplates parses the templates of every layer of the theme.
+// Templates of a theme replace the ones of the same name it inherits from its parent.
+func parseTemplates(t *theme.Theme) (*template.Template, error) {
+	tmpl := template.New("Reports").Funcs(templateFuncs())
+	files, err := t.TemplateFiles()
 	if err != nil {
-		logger.Fatal(err.Error())
+		return nil, err
 	}
-	parsedTemplates, err = template.New("Reports").Funcs(funcs).Parse(string(f))
+	for _, f := range files {
+		if _, err := tmpl.New(f.Name).Parse(string(f.Content)); err != nil {
+			return nil, err
+		}
+	}
+	return tmpl, nil
+}
+
+// loadTheme loads the theme along with the project's overrides of its templates and assets, if any
+func loadTheme(themePath string) (*theme.Theme, error) {
+	t, err := theme.Load(getAbsThemePath(themePath), theme.PluginDefaultThemePath())
 	if err != nil {
-		logger.Fatal(err.Error())
+		return nil, err
+	}
+	if o := theme.GetThemeOverridesPath(); o != "" {
+		return t.WithOverrides(getAbsThemePath(o))
 	}
+	return t, nil
 }
 
 func getAbsThemePath(themePath string) string {
@@ -409,7 +506,24 @@ var projectRoot string
 
 // GenerateReports generates HTML report in the given report dir location
 func GenerateReports(res *SuiteResult, reportsDir, themePath string, searchIndex bool) error {
+	return generateReports(res, reportsDir, themePath, searchIndex, nil)
+}
+
+func generateReports(res *SuiteResult, reportsDir, themePath string, searchIndex bool, build *incrementalBuild) error {
 	readTemplates(themePath)
+	htmlFiles = make([]string, 0)
+	setDurationFormat(env.DurationFormat())
+	setColorScheme(env.ColorScheme())
+	setLocale(env.Locale())
+	setTimezone(env.Timezone())
+	setIssueLinks(env.IssueLinks())
+	setSourceLink(env.SourceLink())
+	setSortOrders(env.SpecsOrder(), env.ScenariosOrder())
+	build.start(themeHash())
+	sortAllScenarios(res)
+	res.hasTagsPage = parsedTemplates.Lookup(tagsTemplate) != nil && hasTags(res)
+	res.hasScenariosPage = parsedTemplates.Lookup(scenariosTemplate) != nil && hasScenarios(res)
+	res.hasNestedSpecs = env.ShouldUseNestedSpecs()
 	indexFilepath := filepath.Join(reportsDir, "index.html")
 	f, err := os.Create(indexFilepath)
 	if err != nil {
@@ -427,7 +541,8 @@ func GenerateReports(res *SuiteResult, reportsDir, themePath string, searchIndex
 		wg.Add(1)
 		res.BasePath = ""
 		go generateIndexPage(res, f, indexFilepath, &wg)
-		if env.ShouldUseNestedSpecs() {
+		if res.hasNestedSpecs {
+			wg.Add(1)
 			go generateIndexPages(res, reportsDir, &wg)
 		}
 		startIndex, slice := 0, runtime.NumCPU()
@@ -441,21 +556,43 @@ func GenerateReports(res *SuiteResult, reportsDir, themePath string, searchIndex
 				slice++
 				relPath, _ := filepath.Rel(projectRoot, r.FileName)
 				env.CreateDirectory(filepath.Join(reportsDir, filepath.Dir(relPath)))
-				htmlFileName := filepath.Join(reportsDir, toHTMLFileName(r.FileName, projectRoot))
+				relHTMLFileName := toHTMLFileName(r.FileName, projectRoot)
+				htmlFileName := filepath.Join(reportsDir, relHTMLFileName)
+				propogateBasePath(r)
+				if build.reuse(res, r, relHTMLFileName, htmlFileName) {
+					continue
+				}
 				sf, err := os.Create(htmlFileName)
 				if err != nil {
 					return err
 				}
 				wg.Add(1)
-				propogateBasePath(r)
 				go func(suiteRes *SuiteResult, specRes *spec, wc io.WriteCloser, htmlFileName string, wg *sync.WaitGroup) {
+					addHTMLFile(htmlFileName)
 					generateSpecPage(suiteRes, specRes, wc, wg)
-					htmlFiles = append(htmlFiles, htmlFileName)
 				}(res, r, sf, htmlFileName, &wg)
 
 			}
 			wg.Wait()
 		}
+		wg.Wait()
+		if err := generatePerformancePage(res, reportsDir); err != nil {
+			return err
+		}
+		if err := generateTimelinePage(res, reportsDir); err != nil {
+			return err
+		}
+		if err := generateTagsPage(res, reportsDir); err != nil {
+			return err
+		}
+		if err := generateScenariosPage(res, reportsDir); err != nil {
+			return err
+		}
+	}
+	if build != nil {
+		if err := build.current.write(reportsDir); err != nil {
+			return err
+		}
 	}
 	if searchIndex {
 		return generateSearchIndex(res, reportsDir)
@@ -507,18 +644,38 @@ func propagateBasepathToItem(i item, basePath string) {
 	}
 }
 
+// GenerateReport renders the report into a staging directory next to reportDir and publishes it only once
+// the pages, assets and screenshots are all in place, so that readers never see a partially written report.
 func GenerateReport(res *SuiteResult, reportDir, themePath string, searchIndex bool) {
-	err := GenerateReports(res, reportDir, themePath, searchIndex)
+	t, err := loadTheme(themePath)
+	if err != nil {
+		logger.Fatalf("Invalid theme %s: %s\n", themePath, err.Error())
+	}
+	staging, err := prepareStagingDir(reportDir)
+	if err != nil {
+		logger.Fatalf("Failed to create staging directory: %s\n", err.Error())
+	}
+	abort := func(format string, err error) {
+		if e := os.RemoveAll(staging); e != nil {
+			logger.Warnf("Failed to remove staging directory %s: %s", staging, e.Error())
+		}
+		logger.Fatalf(format, err.Error())
+	}
+	err = generateReports(res, staging, themePath, searchIndex, newIncrementalBuild(reportDir))
 	if err != nil {
-		logger.Fatalf("Failed to generate reports: %s\n", err.Error())
+		abort("Failed to generate reports: %s\n", err)
 	}
-	err = theme.CopyReportTemplateFiles(themePath, reportDir)
+	err = t.CopyAssets(staging)
 	if err != nil {
-		logger.Fatalf("Error copying template directory :%s\n", err.Error())
+		abort("Error copying template directory :%s\n", err)
 	}
-	copyScreenshotFiles(reportDir)
+	copyScreenshotFiles(staging)
 	if env.ShouldMinifyReports() {
-		minifyHTMLFiles(htmlFiles, reportDir)
+		minifyHTMLFiles(htmlFiles, staging)
+	}
+	err = publishReport(staging, reportDir)
+	if err != nil {
+		abort("Failed to publish report: %s\n", err)
 	}
 	logger.Infof("Successfully generated html-report to => %s\n", filepath.Join(reportDir, "index.html"))
 }
@@ -535,24 +692,21 @@ func containsParseErrors(errors []buildError) bool {
 func generateIndexPage(suiteRes *SuiteResult, w io.Writer, fileName string, wg *sync.WaitGroup) {
 	defer wg.Done()
 	execTemplate("indexPage", w, suiteRes)
-	htmlFiles = append(htmlFiles, fileName)
+	addHTMLFile(fileName)
 }
 
 func generateIndexPages(suiteRes *SuiteResult, reportsDir string, wg *sync.WaitGroup) {
+	defer wg.Done()
 	dirs := make(map[string]int)
 	for _, s := range suiteRes.SpecResults {
 		p, err := filepath.Rel(projectRoot, filepath.Dir(s.FileName))
 		if err != nil {
 			logger.Fatal(err.Error())
 		}
-		childDirs := filepath.SplitList(p)
-		for _, d := range childDirs {
-			if _, ok := dirs[d]; !ok {
-				dirs[d] = 1
-			}
+		for d := p; d != "." && !strings.HasPrefix(d, ".."); d = filepath.Dir(d) {
+			dirs[d] = 1
 		}
 	}
-	delete(dirs, ".")
 	for d := range dirs {
 		dirPath := filepath.Join(reportsDir, d)
 		err := os.MkdirAll(dirPath, common.NewDirectoryPermissions)
@@ -592,7 +746,7 @@ func generateSpecPage(suiteRes *SuiteResult, specRes *spec, wc io.WriteCloser, w
 	if res.AfterSuiteHookFailure != nil && res.AfterSuiteHookFailure.BasePath == "" {
 		res.AfterSuiteHookFailure.BasePath = specRes.BasePath
 	}
-	execTemplate("specPage", wc, struct {
+	execTemplate(specPageTemplate, wc, struct {
 		SuiteRes *SuiteResult
 		SpecRes  *spec
 	}{&res, specRes})
@@ -8,11 +8,13 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"testing"
 
 	"path/filepath"
 
 	helper "github.com/getgauge/html-report/test_helper"
+	"github.com/getgauge/html-report/theme"
 )
 
 type reportGenTest struct {
@@ -23,10 +25,16 @@ type reportGenTest struct {
 }
 
 var whtmlPageStartTag = `<!doctype html>
-<html><head>
+<html lang="en" data-color-scheme="auto"><head>
   <meta http-equiv="X-UA-Compatible" content="IE=9; IE=8; IE=7; IE=EDGE" />
   <meta charset="utf-8" />
   <title>Gauge Test Results</title>
+  <script type="text/javascript">
+    try {
+      var colorScheme = localStorage.getItem('gauge-html-report-color-scheme');
+      if (colorScheme) document.documentElement.setAttribute("data-color-scheme", colorScheme);
+    } catch (e) {}
+  </script>
   <link rel="shortcut icon" type="image/x-icon" href="images/favicon.ico">
   <link rel="stylesheet" type="text/css" href="css/open-sans.css">
   <link rel="stylesheet" type="text/css" href="css/font-awesome.css">
@@ -34,6 +42,7 @@ var whtmlPageStartTag = `<!doctype html>
   <link rel="stylesheet" type="text/css" href="css/style.css" />
 </head>
 <body>
+<a class="skip-link" href="#content">Skip to content</a>
 <header class="top">
   <div class="header">
     <div class="container">
@@ -41,16 +50,25 @@ var whtmlPageStartTag = `<!doctype html>
         <a href="."><img src="images/gaugeLogo.png" alt="Report logo"></a>
       </div>
       <h2 class="project">Project: projname</h2>
+      <label class="color-scheme">Colors
+        <select id="color-scheme">
+          <option value="auto">Automatic</option>
+          <option value="light">Light</option>
+          <option value="dark">Dark</option>
+          <option value="high-contrast">High contrast</option>
+        </select>
+      </label>
     </div>
   </div>
 </header>
-<main class="main-container">
+<main id="content" class="main-container">
 <div class="container">`
 
 var wChartDiv = `<div class="report-overview">
 <div class="report_chart">
 <div class="chart">
-<svg id="pie-chart" data-results="2,39,0" data-total="41">
+<svg id="pie-chart" role="img" aria-labelledby="pie-chart-title" data-results="2,39,0" data-total="41">
+  	<title id="pie-chart-title">Specifications: 2 failed, 39 passed, 0 skipped of 41</title>
   	<path class="status failed" />
 	<path class="shadow failed" data-status="failed"><title>Failed: 2/41</title></path>
 	<path class="status passed" />
@@ -64,16 +82,16 @@ var wChartDiv = `<div class="report-overview">
 var wResCntDiv = `
   <div class="report_test-results">
 		<div class="report_test-result specs">
-				<div class="total-specs" title="Filter all specs"><span class="txt">Total specs</span><span class="value">41</span></div>
-				<div class="fail spec-filter" data-status="failed" title="Filter failed specs"><span class="value">2</span></div>
-				<div class="pass spec-filter" data-status="passed" title="Filter passed specs"><span class="value">39</span></div>
-				<div class="skip spec-filter" data-status="skipped" title="Filter skipped specs"><span class="value">0</span></div>
+				<div class="total-specs" role="button" tabindex="0" aria-pressed="false" title="Filter all specs"><span class="txt">Total specs</span><span class="value">41</span></div>
+				<div class="fail spec-filter" role="button" tabindex="0" aria-pressed="false" data-status="failed" title="Filter failed specs"><span class="value">2</span><span class="sr-only"> failed specs</span></div>
+				<div class="pass spec-filter" role="button" tabindex="0" aria-pressed="false" data-status="passed" title="Filter passed specs"><span class="value">39</span><span class="sr-only"> passed specs</span></div>
+				<div class="skip spec-filter" role="button" tabindex="0" aria-pressed="false" data-status="skipped" title="Filter skipped specs"><span class="value">0</span><span class="sr-only"> skipped specs</span></div>
 		</div>
 		<div class="report_test-result scenarios">
 				<div class="total-scenarios"><span class="txt">Total scenario</span><span class="value">41</span></div>
-				<div class="fail scenario-stats" data-status="failed"><span class="value">2</span></div>
-				<div class="pass scenario-stats" data-status="passed"><span class="value">39</span></div>
-				<div class="skip scenario-stats" data-status="skipped"><span class="value">0</span></div>
+				<div class="fail scenario-stats" data-status="failed"><span class="value">2</span><span class="sr-only"> failed scenarios</span></div>
+				<div class="pass scenario-stats" data-status="passed"><span class="value">39</span><span class="sr-only"> passed scenarios</span></div>
+				<div class="skip scenario-stats" data-status="skipped"><span class="value">0</span><span class="sr-only"> skipped scenarios</span></div>
 		</div>
   </div>`
 
@@ -98,7 +116,7 @@ var wSuccRateLi = `
 var wExecTimeLi = `
      <li>
         <label>Total Time </label>
-        <span>00:01:53</span>
+        <span>1m 53s</span>
       </li>`
 
 var wTimestampLi = `
@@ -113,40 +131,52 @@ var wTimestampLi = `
 var wSidebarAside = `<aside class="sidebar">
   <h3 class="title">Specifications</h3>
   <div class="searchbar">
-    <input id="searchSpecifications" placeholder="Type specification or tag name" type="text" />
-    <i class="fa fa-search"></i>
+    <input id="searchSpecifications" placeholder="Type specification or tag name" type="text" aria-label="Search specifications by name or tag" />
+    <i class="fa fa-search" aria-hidden="true"></i>
   </div>
   <div class="specs-sorting">
-			<div class="sort sort-specs-name" data-sort-by="specs-name"><span class="sort-icons"><i class="fa fa-caret-up"></i><i class="fa fa-caret-down"></i></span><span>Name</span></div>
-			<div class="sort sort-execution-time" data-sort-by="execution-time"><span class="sort-icons"><i class="fa fa-caret-up"></i><i class="fa fa-caret-down"></i></span><span>Execution time</span></div>
+			<div class="sort sort-specs-name" role="button" tabindex="0" aria-label="Sort by name" data-sort-by="specs-name"><span class="sort-icons" aria-hidden="true"><i class="fa fa-caret-up"></i><i class="fa fa-caret-down"></i></span><span>Name</span></div>
+			<div class="sort sort-execution-time" role="button" tabindex="0" aria-label="Sort by execution time" data-sort-by="execution-time"><span class="sort-icons" aria-hidden="true"><i class="fa fa-caret-up"></i><i class="fa fa-caret-down"></i></span><span>Execution time</span></div>
 	</div>
+  <div class="sort-order specs-order">
+    <label>Order by <select data-order-of="specs">
+      <option value="status">Status</option>
+      <option value="source" selected>Source order</option>
+      <option value="execution">Execution order</option>
+      <option value="name">Name</option>
+      <option value="duration">Execution time</option>
+    </select></label>
+  </div>
   <div id="listOfSpecifications">
     <ul id="scenarios" class="spec-list">
-		<a href="passing_spec.html">
-    	<li class="passed spec-name">
-	      <span id="scenarioName" class="scenarioname">Passing Spec</span>
-	      <span id="time" class="time">00:01:04</span>
-    	</li>
-		</a>
-		<a href="failing_spec.html">
-    	<li class="failed spec-name">
-	      <span id="scenarioName" class="scenarioname">Failing Spec</span>
-	      <span id="time" class="time">00:00:30</span>
-    	</li>
-		</a>
-		<a href="skipped_spec.html">
-    	<li class="skipped spec-name">
-	      <span id="scenarioName" class="scenarioname">Skipped Spec</span>
-	      <span id="time" class="time">00:00:00</span>
-    	</li>
-		</a>
+		<li class="passed spec-name">
+		    <a href="passing_spec.html" data-execution-index="0" data-source-index="2">
+		        <span class="scenarioname">Passing Spec</span>
+		        <span class="time" data-execution-time="64000">1m 4s</span>
+		        <span class="sr-only">Passed</span>
+		    </a>
+		</li>
+		<li class="failed spec-name">
+		    <a href="failing_spec.html" data-execution-index="1" data-source-index="0">
+		        <span class="scenarioname">Failing Spec</span>
+		        <span class="time" data-execution-time="30000">30s</span>
+		        <span class="sr-only">Failed</span>
+		    </a>
+		</li>
+		<li class="skipped spec-name">
+		    <a href="skipped_spec.html" data-execution-index="2" data-source-index="1">
+		        <span class="scenarioname">Skipped Spec</span>
+		        <span class="time" data-execution-time="0">0ms</span>
+		        <span class="sr-only">Skipped</span>
+		    </a>
+		</li>
     </ul>
   </div>
 </aside>`
 
 var wHookFailureWithScreenhotDiv = `<div class="error-container failed" data-tablerow='0'>
 <div class="error-heading">BeforeSuite Failed:<span class="error-message"> SomeError</span></div>
-  <div class="toggle-show">
+  <div class="toggle-show" role="button" tabindex="0" aria-expanded="false" data-show-label="[Show details]" data-hide-label="[Hide details]">
     [Show details]
   </div>
   <div class="exception-container hidden">
@@ -156,7 +186,7 @@ var wHookFailureWithScreenhotDiv = `<div class="error-container failed" data-tab
 		<div class="screenshot-container">
 			<div class="screenshot">
 				<a href="../images/iVBO" rel="lightbox">
-					<img src="../images/iVBO" class="screenshot-thumbnail" />
+					<img src="../images/iVBO" class="screenshot-thumbnail" alt="Failure screenshot" />
 				</a>
 			</div>
 		</div>
@@ -165,7 +195,7 @@ var wHookFailureWithScreenhotDiv = `<div class="error-container failed" data-tab
 
 var wHookFailureWithoutScreenhotDiv = `<div class="error-container failed" data-tablerow='0'>
   <div class="error-heading">BeforeSuite Failed:<span class="error-message"> SomeError</span></div>
-  <div class="toggle-show">
+  <div class="toggle-show" role="button" tabindex="0" aria-expanded="false" data-show-label="[Show details]" data-hide-label="[Hide details]">
     [Show details]
   </div>
   <div class="exception-container hidden">
@@ -191,11 +221,11 @@ var wSpecHeaderStartWithTags = `<div id="specificationContainer" class="details"
 		<div class="spec-filename">
 			<label for="specFileName">File Path</label>
 			<input id="specFileName" value="/tmp/gauge/specs/foobar.spec" readonly/>
-			<button class="clipboard-btn" data-clipboard-target="#specFileName" title="Copy to Clipboard">
+			<button type="button" class="clipboard-btn" data-clipboard-target="#specFileName" title="Copy to Clipboard" aria-label="Copy file path to clipboard">
 				<i class="fa fa-clipboard" aria-hidden="true" title="Copy to Clipboard"></i>
 			</button>
 		</div>
-		<span class="time">00:01:01</span>
+		<span class="time">1m 1s</span>
 	</div>`
 
 var wTagsDiv = `<div class="tags scenario_tags contentSection">
@@ -213,15 +243,15 @@ var wSpecCommentsWithTableTag = `
     <th>Count</th>
   </tr>
   <tbody data-rowCount=3>
-    <tr class="row-selector passed selected" data-rowIndex='0'>
+    <tr class="row-selector passed selected" tabindex="0" data-rowIndex='0'>
       <td>Gauge</td>
       <td>3</td>
     </tr>
-    <tr class="row-selector failed" data-rowIndex='1'>
+    <tr class="row-selector failed" tabindex="0" data-rowIndex='1'>
       <td>Mingle</td>
       <td>2</td>
     </tr>
-    <tr class="row-selector skipped" data-rowIndex='2'>
+    <tr class="row-selector skipped" tabindex="0" data-rowIndex='2'>
       <td>foobar</td>
       <td>1</td>
     </tr>
@@ -251,28 +281,33 @@ var wScenarioDataTableDiv = `
         <th>Browser</th><th>Status</th>
       </tr>
       <tbody data-rowCount=2>
-        <tr class="row-selector passed selected" data-scenario-row-index='0'>
+        <tr class="row-selector passed selected" tabindex="0" data-scenario-row-index='0'>
           <td>Chrome</td><td>Pass</td>
         </tr>
-        <tr class="row-selector failed" data-scenario-row-index='1'>
+        <tr class="row-selector failed" tabindex="0" data-scenario-row-index='1'>
           <td>Firefox</td><td>Fail</td>
         </tr>
       </tbody>
     </table>`
 
-var wscenarioHeaderStartDiv = `<div class="scenario-head">
+var wscenarioHeaderStartDiv = `<div class="scenario-head" data-execution-index="0" data-source-line="0">
+  <h3 class="head borderBottom">Scenario Heading</h3>
+  <span class="time" data-execution-time="61000">1m 1s</span>`
+
+var wscenarioHeaderPassedOnRerunStartDiv = `<div class="scenario-head" data-execution-index="0" data-source-line="0">
   <h3 class="head borderBottom">Scenario Heading</h3>
-  <span class="time">00:01:01</span>`
+  <span class="scenario-rerun">Passed on rerun</span>
+  <span class="time" data-execution-time="61000">1m 1s</span>`
 
 var wPassStepStartDiv = `<div class="step">
-  <h5 class="execution-time"><span class="time">Execution Time : 00:03:31</span></h5>
+  <h5 class="execution-time"><span class="time">Execution Time : 3m 31s</span></h5>
   <div class="step-info passed">
     <ul>
       <li class="step">
         <div class="step-txt">`
 
 var wFailStepStartDiv = `<div class="step">
-  <h5 class="execution-time"><span class="time">Execution Time : 00:03:31</span></h5>
+  <h5 class="execution-time"><span class="time">Execution Time : 3m 31s</span></h5>
   <div class="step-info failed">
     <ul>
       <li class="step">
@@ -297,11 +332,11 @@ var wSkippedStepWithSkippedReason = `<div class="message-container">
 
 var wStepWithFileParam = `
     <span>Say</span>
-		<span class="modal-link">&lt;file:hello.txt&gt;</span>
-		<div class="modal">
+		<span class="modal-link" role="button" tabindex="0" aria-haspopup="dialog">&lt;file:hello.txt&gt;</span>
+		<div class="modal" role="dialog" aria-modal="true" aria-label="">
 			<h2 class="modal-title"></h2>
-			<span class="close">&times;</span>
-			<div class="modal-content">
+			<button type="button" class="close" aria-label="Close">&times;</button>
+			<div class="modal-content" tabindex="0">
 					<pre>good morning</pre>
 			</div>
 			</div>
@@ -310,11 +345,11 @@ var wStepWithFileParam = `
 
 var wStepWithSpecialTableParam = `
     <span>Say</span>
-		<span class="modal-link">&lt;table:hello.csv&gt;</span>
-		<div class="modal">
+		<span class="modal-link" role="button" tabindex="0" aria-haspopup="dialog">&lt;table:hello.csv&gt;</span>
+		<div class="modal" role="dialog" aria-modal="true" aria-label="">
 			<h2 class="modal-title"></h2>
-			<span class="close">&times;</span>
-			<div class="modal-content">
+			<button type="button" class="close" aria-label="Close">&times;</button>
+			<div class="modal-content" tabindex="0">
 				<pre></pre>
 			</div>
 		</div>
@@ -374,7 +409,7 @@ var wBeforeSuiteScreenshotDiv = `<div class="suite_screenshots">
 	<div class="screenshot-container">
 		<div class="screenshot">
 			<a href="../images/Before Suite Screenshot" rel="lightbox">
-				<img src="../images/Before Suite Screenshot" class="screenshot-thumbnail" />
+				<img src="../images/Before Suite Screenshot" class="screenshot-thumbnail" alt="Screenshot" />
 			</a>
 		</div>
 	</div>
@@ -385,7 +420,7 @@ var wBeforeSuiteScreenshotBytesDiv = `<div class="suite_screenshots">
 	<div class="screenshot-container">
 		<div class="screenshot">
 			<a href="data:image/png;base64,Before Suite Screenshot" rel="lightbox">
-				<img src="data:image/png;base64,Before Suite Screenshot" class="screenshot-thumbnail" />
+				<img src="data:image/png;base64,Before Suite Screenshot" class="screenshot-thumbnail" alt="Screenshot" />
 			</a>
 		</div>
 	</div>
@@ -396,7 +431,7 @@ var wAfterSuiteScreenshotDiv = `<div class="suite_screenshots">
 	<div class="screenshot-container">
 		<div class="screenshot">
 			<a href="../images/After Suite Screenshot" rel="lightbox">
-				<img src="../images/After Suite Screenshot" class="screenshot-thumbnail" />
+				<img src="../images/After Suite Screenshot" class="screenshot-thumbnail" alt="Screenshot" />
 			</a>
 		</div>
 	</div>
@@ -407,7 +442,7 @@ var wAfterSuiteScreenshotBytesDiv = `<div class="suite_screenshots">
 	<div class="screenshot-container">
 		<div class="screenshot">
 			<a href="data:image/png;base64,After Suite Screenshot" rel="lightbox">
-				<img src="data:image/png;base64,After Suite Screenshot" class="screenshot-thumbnail" />
+				<img src="data:image/png;base64,After Suite Screenshot" class="screenshot-thumbnail" alt="Screenshot" />
 			</a>
 		</div>
 	</div>
@@ -418,7 +453,7 @@ var wBeforeAndAfterSuiteScreenshotDiv = `<div class="suite_screenshots">
 	<div class="screenshot-container">
 		<div class="screenshot">
 			<a href="../images/Before Suite Screenshot" rel="lightbox">
-				<img src="../images/Before Suite Screenshot" class="screenshot-thumbnail" />
+				<img src="../images/Before Suite Screenshot" class="screenshot-thumbnail" alt="Screenshot" />
 			</a>
 		</div>
 	</div>
@@ -433,7 +468,7 @@ var stepWithBracketsInFragment = &step{
 	},
 	Result: &result{
 		Status:        pass,
-		ExecutionTime: "00:03:31",
+		ExecutionTime: 211000,
 	},
 }
 
@@ -449,7 +484,7 @@ var stepWithFileParam = &step{
 	},
 	Result: &result{
 		Status:        pass,
-		ExecutionTime: "00:03:31",
+		ExecutionTime: 211000,
 	},
 }
 
@@ -475,7 +510,7 @@ var stepWithSpecialTableParam = &step{
 	},
 	Result: &result{
 		Status:        pass,
-		ExecutionTime: "00:03:31",
+		ExecutionTime: 211000,
 	},
 }
 
@@ -485,41 +520,43 @@ var skippedStepRes = &result{
 }
 
 var reportGenTests = []reportGenTest{
-	{"generate html page start with project name", "htmlPageStartTag", &overview{ProjectName: "projname"}, whtmlPageStartTag},
-	{"generate report overview with tags", "reportOverviewTag", &overview{"projname", "default", "foo", 34, "00:01:53", "Jun 3, 2016 at 12:29pm", &summary{41, 2, 39, 0}, &summary{41, 2, 39, 0}, "../", []string{}, []string{}, []string{}, []string{}, []string{}, []string{}},
+	{"generate html page start with project name", "htmlPageStartTag", &overview{ProjectName: "projname", ColorScheme: "auto"}, whtmlPageStartTag},
+	{"generate report overview with tags", "reportOverviewTag", &overview{ProjectName: "projname", Env: "default", Tags: "foo", SuccessRate: 34, ExecutionTime: 113000, Timestamp: "Jun 3, 2016 at 12:29pm", Summary: &summary{Total: 41, Failed: 2, Passed: 39}, ScenarioSummary: &summary{Total: 41, Failed: 2, Passed: 39}, BasePath: "../"},
 		wChartDiv + wResCntDiv + wEnvLi + wTagsLi + wSuccRateLi + wExecTimeLi + wTimestampLi},
-	{"generate report overview without tags", "reportOverviewTag", &overview{"projname", "default", "", 34, "00:01:53", "Jun 3, 2016 at 12:29pm", &summary{41, 2, 39, 0}, &summary{41, 2, 39, 0}, "../", []string{}, []string{}, []string{}, []string{}, []string{}, []string{}},
+	{"generate report overview without tags", "reportOverviewTag", &overview{ProjectName: "projname", Env: "default", SuccessRate: 34, ExecutionTime: 113000, Timestamp: "Jun 3, 2016 at 12:29pm", Summary: &summary{Total: 41, Failed: 2, Passed: 39}, ScenarioSummary: &summary{Total: 41, Failed: 2, Passed: 39}, BasePath: "../"},
 		wChartDiv + wResCntDiv + wEnvLi + wSuccRateLi + wExecTimeLi + wTimestampLi},
-	{"generate suite messages with before hook message", "suiteMessagesDiv", &overview{"projname", "default", "", 34, "00:01:53", "Jun 3, 2016 at 12:29pm", &summary{41, 2, 39, 0}, &summary{41, 2, 39, 0}, "../", []string{"Before Suite message"}, []string{}, []string{}, []string{}, []string{}, []string{}},
+	{"generate suite messages with before hook message", "suiteMessagesDiv", &overview{ProjectName: "projname", Env: "default", SuccessRate: 34, ExecutionTime: 113000, Timestamp: "Jun 3, 2016 at 12:29pm", Summary: &summary{Total: 41, Failed: 2, Passed: 39}, ScenarioSummary: &summary{Total: 41, Failed: 2, Passed: 39}, BasePath: "../", PreHookMessages: []string{"Before Suite message"}},
 		wBeforeSuiteMessageDiv},
-	{"generate suite messages with after hook message", "suiteMessagesDiv", &overview{"projname", "default", "", 34, "00:01:53", "Jun 3, 2016 at 12:29pm", &summary{41, 2, 39, 0}, &summary{41, 2, 39, 0}, "../", []string{}, []string{"After Suite message"}, []string{}, []string{}, []string{}, []string{}},
+	{"generate suite messages with after hook message", "suiteMessagesDiv", &overview{ProjectName: "projname", Env: "default", SuccessRate: 34, ExecutionTime: 113000, Timestamp: "Jun 3, 2016 at 12:29pm", Summary: &summary{Total: 41, Failed: 2, Passed: 39}, ScenarioSummary: &summary{Total: 41, Failed: 2, Passed: 39}, BasePath: "../", PostHookMessages: []string{"After Suite message"}},
 		wAfterSuiteMessageDiv},
-	{"generate suite messages with before and after hook message", "suiteMessagesDiv", &overview{"projname", "default", "", 34, "00:01:53", "Jun 3, 2016 at 12:29pm", &summary{41, 2, 39, 0}, &summary{41, 2, 39, 0}, "../", []string{"Before Suite message"}, []string{"After Suite message"}, []string{}, []string{}, []string{}, []string{}},
+	{"generate suite messages with before and after hook message", "suiteMessagesDiv", &overview{ProjectName: "projname", Env: "default", SuccessRate: 34, ExecutionTime: 113000, Timestamp: "Jun 3, 2016 at 12:29pm", Summary: &summary{Total: 41, Failed: 2, Passed: 39}, ScenarioSummary: &summary{Total: 41, Failed: 2, Passed: 39}, BasePath: "../", PreHookMessages: []string{"Before Suite message"}, PostHookMessages: []string{"After Suite message"}},
 		wBeforeAndAfterSuiteMessageDiv},
-	{"generate suite screenshots with before hook screenshot", "suiteScreenshotsDiv", &overview{"projname", "default", "", 34, "00:01:53", "Jun 3, 2016 at 12:29pm", &summary{41, 2, 39, 0}, &summary{41, 2, 39, 0}, "../", []string{}, []string{}, []string{}, []string{}, []string{"Before Suite Screenshot"}, []string{}},
+	{"generate suite screenshots with before hook screenshot", "suiteScreenshotsDiv", &overview{ProjectName: "projname", Env: "default", SuccessRate: 34, ExecutionTime: 113000, Timestamp: "Jun 3, 2016 at 12:29pm", Summary: &summary{Total: 41, Failed: 2, Passed: 39}, ScenarioSummary: &summary{Total: 41, Failed: 2, Passed: 39}, BasePath: "../", PreHookScreenshotFiles: []string{"Before Suite Screenshot"}},
 		wBeforeSuiteScreenshotDiv},
-	{"generate suite screenshots with before hook screenshot bytes", "suiteScreenshotsDiv", &overview{"projname", "default", "", 34, "00:01:53", "Jun 3, 2016 at 12:29pm", &summary{41, 2, 39, 0}, &summary{41, 2, 39, 0}, "../", []string{}, []string{}, []string{"Before Suite Screenshot"}, []string{}, []string{}, []string{}},
+	{"generate suite screenshots with before hook screenshot bytes", "suiteScreenshotsDiv", &overview{ProjectName: "projname", Env: "default", SuccessRate: 34, ExecutionTime: 113000, Timestamp: "Jun 3, 2016 at 12:29pm", Summary: &summary{Total: 41, Failed: 2, Passed: 39}, ScenarioSummary: &summary{Total: 41, Failed: 2, Passed: 39}, BasePath: "../", PreHookScreenshots: []string{"Before Suite Screenshot"}},
 		wBeforeSuiteScreenshotBytesDiv},
-	{"generate suite screenshots with after hook screenshot", "suiteScreenshotsDiv", &overview{"projname", "default", "", 34, "00:01:53", "Jun 3, 2016 at 12:29pm", &summary{41, 2, 39, 0}, &summary{41, 2, 39, 0}, "../", []string{}, []string{}, []string{}, []string{}, []string{"After Suite Screenshot"}, []string{}},
+	{"generate suite screenshots with after hook screenshot", "suiteScreenshotsDiv", &overview{ProjectName: "projname", Env: "default", SuccessRate: 34, ExecutionTime: 113000, Timestamp: "Jun 3, 2016 at 12:29pm", Summary: &summary{Total: 41, Failed: 2, Passed: 39}, ScenarioSummary: &summary{Total: 41, Failed: 2, Passed: 39}, BasePath: "../", PreHookScreenshotFiles: []string{"After Suite Screenshot"}},
 		wAfterSuiteScreenshotDiv},
-	{"generate suite screenshots with after hook screenshot bytes", "suiteScreenshotsDiv", &overview{"projname", "default", "", 34, "00:01:53", "Jun 3, 2016 at 12:29pm", &summary{41, 2, 39, 0}, &summary{41, 2, 39, 0}, "../", []string{}, []string{}, []string{}, []string{"After Suite Screenshot"}, []string{}, []string{}},
+	{"generate suite screenshots with after hook screenshot bytes", "suiteScreenshotsDiv", &overview{ProjectName: "projname", Env: "default", SuccessRate: 34, ExecutionTime: 113000, Timestamp: "Jun 3, 2016 at 12:29pm", Summary: &summary{Total: 41, Failed: 2, Passed: 39}, ScenarioSummary: &summary{Total: 41, Failed: 2, Passed: 39}, BasePath: "../", PostHookScreenshots: []string{"After Suite Screenshot"}},
 		wAfterSuiteScreenshotBytesDiv},
-	{"generate suite screenshots with before and after hook screenshot", "suiteScreenshotsDiv", &overview{"projname", "default", "", 34, "00:01:53", "Jun 3, 2016 at 12:29pm", &summary{41, 2, 39, 0}, &summary{41, 2, 39, 0}, "../", []string{}, []string{}, []string{}, []string{}, []string{"Before Suite Screenshot"}, []string{}},
+	{"generate suite screenshots with before and after hook screenshot", "suiteScreenshotsDiv", &overview{ProjectName: "projname", Env: "default", SuccessRate: 34, ExecutionTime: 113000, Timestamp: "Jun 3, 2016 at 12:29pm", Summary: &summary{Total: 41, Failed: 2, Passed: 39}, ScenarioSummary: &summary{Total: 41, Failed: 2, Passed: 39}, BasePath: "../", PreHookScreenshotFiles: []string{"Before Suite Screenshot"}},
 		wBeforeAndAfterSuiteScreenshotDiv},
 	{"generate sidebar with appropriate pass/fail/skip class", "sidebarDiv", &sidebar{
 		IsBeforeHookFailure: false,
 		Specs: []*specsMeta{
-			newSpecsMeta("Passing Spec", "00:01:04", false, false, nil, "passing_spec.html"),
-			newSpecsMeta("Failing Spec", "00:00:30", true, false, nil, "failing_spec.html"),
-			newSpecsMeta("Skipped Spec", "00:00:00", false, true, nil, "skipped_spec.html"),
-		}}, wSidebarAside},
+			withIndexes(newSpecsMeta("Passing Spec", 64000, false, false, nil, "passing_spec.html"), 0, 2),
+			withIndexes(newSpecsMeta("Failing Spec", 30000, true, false, nil, "failing_spec.html"), 1, 0),
+			withIndexes(newSpecsMeta("Skipped Spec", 0, false, true, nil, "skipped_spec.html"), 2, 1),
+		},
+		Order: sourceOrder,
+	}, wSidebarAside},
 	{"do not generate sidebar if presuitehook failure", "sidebarDiv", &sidebar{
 		IsBeforeHookFailure: true,
 		Specs:               []*specsMeta{},
 	}, ""},
 	{"generate hook failure div with screenshot", "hookFailureDiv", newHookFailure("../", "BeforeSuite", "SomeError", "iVBO", "Stack trace"), wHookFailureWithScreenhotDiv},
 	{"generate hook failure div without screenshot", "hookFailureDiv", newHookFailure("../", "BeforeSuite", "SomeError", "", "Stack trace"), wHookFailureWithoutScreenhotDiv},
-	{"generate spec header with tags", "specHeaderStartTag", &specHeader{"Spec heading", "00:01:01", "/tmp/gauge/specs/foobar.spec", []string{"foo", "bar"}, &summary{0, 0, 0, 0}}, wSpecHeaderStartWithTags},
+	{"generate spec header with tags", "specHeaderStartTag", &specHeader{SpecName: "Spec heading", ExecutionTime: 61000, FileName: "/tmp/gauge/specs/foobar.spec", Tags: []string{"foo", "bar"}, Summary: &summary{}}, wSpecHeaderStartWithTags},
 	{"generate div for tags", "tagsDiv", &specHeader{Tags: []string{"tag1", "tag2"}}, wTagsDiv},
 	{"generate spec comments with data table (if present)", "specCommentsAndTableTag", newSpec(true), wSpecCommentsWithTableTag},
 	{"generate spec comments without data table", "specCommentsAndTableTag", newSpec(false), wSpecCommentsWithoutTableTag},
@@ -534,7 +571,8 @@ var reportGenTests = []reportGenTest{
 		ScenarioDataTable:     &table{Headers: []string{"Browser", "Status"}, Rows: []*row{{Cells: []string{"Chrome", "Pass"}, Result: pass}, {Cells: []string{"Firefox", "Fail"}, Result: fail}}},
 		IsScenarioTableDriven: true,
 	}, wScenarioDataTableDiv},
-	{"generate scenario header", "scenarioHeaderStartDiv", &scenario{Heading: "Scenario Heading", ExecutionTime: "00:01:01"}, wscenarioHeaderStartDiv},
+	{"generate scenario header", "scenarioHeaderStartDiv", &scenario{Heading: "Scenario Heading", ExecutionTime: 61000}, wscenarioHeaderStartDiv},
+	{"generate scenario header for scenario passed on rerun", "scenarioHeaderStartDiv", &scenario{Heading: "Scenario Heading", ExecutionTime: 61000, ExecutionStatus: pass, IsRerun: true, PreviousExecutionStatus: fail}, wscenarioHeaderPassedOnRerunStartDiv},
 	{"generate pass step start div", "stepStartDiv", newStep(pass), wPassStepStartDiv},
 	{"generate fail step start div", "stepStartDiv", newStep(fail), wFailStepStartDiv},
 	{"generate skipped step start div", "stepStartDiv", newStep(skip), wSkipStepStartDiv},
@@ -576,7 +614,7 @@ func newHookFailure(basePath, name, errMsg, screenshot, stacktrace string) *hook
 	}
 }
 
-func newSpecsMeta(name, execTime string, failed, skipped bool, tags []string, fileName string) *specsMeta {
+func newSpecsMeta(name string, execTime duration, failed, skipped bool, tags []string, fileName string) *specsMeta {
 	return &specsMeta{
 		SpecName:      name,
 		ExecutionTime: execTime,
@@ -587,6 +625,12 @@ func newSpecsMeta(name, execTime string, failed, skipped bool, tags []string, fi
 	}
 }
 
+func withIndexes(sm *specsMeta, executionIndex, sourceIndex int) *specsMeta {
+	sm.ExecutionIndex = executionIndex
+	sm.SourceIndex = sourceIndex
+	return sm
+}
+
 func newSpec(withTable bool) *spec {
 	t := &table{
 		Headers: []string{"Word", "Count"},
@@ -641,7 +685,7 @@ func newStep(s status) *step {
 		},
 		Result: &result{
 			Status:        s,
-			ExecutionTime: "00:03:31",
+			ExecutionTime: 211000,
 		},
 	}
 }
@@ -660,6 +704,50 @@ func TestGetAbsThemePathForRelPath(t *testing.T) {
 	projectRoot = oldProjectRoot
 }
 
+func TestReadTemplatesOfThemeInheritingFromParent(t *testing.T) {
+	dir := t.TempDir()
+	manifest := fmt.Sprintf(`{"name": "company", "parent": %q, "overrides": ["bodyFooterTag"]}`, filepath.ToSlash(templateBasePath))
+	if err := os.WriteFile(filepath.Join(dir, theme.ManifestFile), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "views"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	footer := `{{define "bodyFooterTag"}}<footer>ACME</footer>{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "views", "footer.tmpl"), []byte(footer), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer readTemplates(templateBasePath)
+
+	readTemplates(dir)
+
+	buf := new(bytes.Buffer)
+	execTemplate("bodyFooterTag", buf, nil)
+	checkEqual(t, "", "<footer>ACME</footer>", buf.String())
+	if parsedTemplates.Lookup("specPage") == nil {
+		t.Errorf("Expected templates of the parent theme to be inherited")
+	}
+}
+
+func TestReadTemplatesWithProjectOverrides(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "views"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	footer := `{{define "bodyFooterTag"}}<footer>ACME</footer>{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "views", "footer.tmpl"), []byte(footer), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { readTemplates(templateBasePath) })
+	t.Setenv("GAUGE_HTML_REPORT_THEME_OVERRIDES", dir)
+
+	readTemplates(templateBasePath)
+
+	buf := new(bytes.Buffer)
+	execTemplate("bodyFooterTag", buf, nil)
+	checkEqual(t, "", "<footer>ACME</footer>", buf.String())
+}
+
 func BenchmarkGenerateReport(b *testing.B) {
 	ps := &SuiteResult{
 		ProjectName: "Foo",
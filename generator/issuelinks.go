@@ -0,0 +1,84 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getgauge/html-report/logger"
+)
+
+const issueLinkSeparator = "=>"
+
+// issueLink turns the text matching a pattern, e.g. a ticket id in a tag, into a link to the issue tracker.
+// The URL can refer to the match as $0 and to its groups as $1, $2 or ${name}.
+type issueLink struct {
+	pattern *regexp.Regexp
+	url     string
+}
+
+var issueLinks []issueLink
+
+// setIssueLinks reads the links from entries of the form `pattern => url`, separated by semicolons or newlines.
+func setIssueLinks(config string) {
+	issueLinks = nil
+	for _, entry := range strings.FieldsFunc(config, func(r rune) bool { return r == ';' || r == '\n' }) {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		pattern, url, found := strings.Cut(entry, issueLinkSeparator)
+		pattern, url = strings.TrimSpace(pattern), strings.TrimSpace(url)
+		if !found || pattern == "" || url == "" {
+			logger.Warnf("Invalid issue link %s, expected <pattern> %s <url>", strings.TrimSpace(entry), issueLinkSeparator)
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			logger.Warnf("Invalid issue link pattern %s: %s", pattern, err.Error())
+			continue
+		}
+		issueLinks = append(issueLinks, issueLink{pattern: re, url: url})
+	}
+}
+
+// linkIssues escapes the text for HTML and links the parts of it matching the issue links. Where matches
+// overlap, the one starting first wins, and of those starting at the same position the one configured first.
+func linkIssues(text string) string {
+	if len(issueLinks) == 0 {
+		return template.HTMLEscapeString(text)
+	}
+	type match struct {
+		start, end int
+		url        string
+	}
+	var matches []match
+	for _, l := range issueLinks {
+		for _, m := range l.pattern.FindAllStringSubmatchIndex(text, -1) {
+			if m[0] == m[1] {
+				continue
+			}
+			matches = append(matches, match{m[0], m[1], string(l.pattern.ExpandString(nil, l.url, text, m))})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	var b strings.Builder
+	pos := 0
+	for _, m := range matches {
+		if m.start < pos {
+			continue
+		}
+		b.WriteString(template.HTMLEscapeString(text[pos:m.start]))
+		b.WriteString(`<a class="issue-link" href="` + template.HTMLEscapeString(m.url) + `" target="_blank" rel="noopener">`)
+		b.WriteString(template.HTMLEscapeString(text[m.start:m.end]))
+		b.WriteString("</a>")
+		pos = m.end
+	}
+	b.WriteString(template.HTMLEscapeString(text[pos:]))
+	return b.String()
+}
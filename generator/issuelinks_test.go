@@ -0,0 +1,65 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLinkIssues(t *testing.T) {
+	setIssueLinks(`JIRA-[0-9]+ => https://jira.example.com/browse/$0; bug:(?P<id>[0-9]+) => https://bugs.example.com/show_bug.cgi?id=${id}&lang=en`)
+	defer setIssueLinks("")
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"smoke", "smoke"},
+		{"JIRA-1234", `<a class="issue-link" href="https://jira.example.com/browse/JIRA-1234" target="_blank" rel="noopener">JIRA-1234</a>`},
+		{"bug:567", `<a class="issue-link" href="https://bugs.example.com/show_bug.cgi?id=567&amp;lang=en" target="_blank" rel="noopener">bug:567</a>`},
+		{"Login <fails> for JIRA-1 and JIRA-2", `Login &lt;fails&gt; for <a class="issue-link" href="https://jira.example.com/browse/JIRA-1" target="_blank" rel="noopener">JIRA-1</a> and <a class="issue-link" href="https://jira.example.com/browse/JIRA-2" target="_blank" rel="noopener">JIRA-2</a>`},
+	}
+	for _, test := range tests {
+		checkEqual(t, test.text, test.want, linkIssues(test.text))
+	}
+}
+
+func TestLinkIssuesPrefersFirstMatch(t *testing.T) {
+	setIssueLinks("ABC-[0-9]+ => https://a.example.com/$0\n[A-Z]+-[0-9]+ => https://b.example.com/$0")
+	defer setIssueLinks("")
+
+	checkEqual(t, "", `<a class="issue-link" href="https://a.example.com/ABC-1" target="_blank" rel="noopener">ABC-1</a>`, linkIssues("ABC-1"))
+	checkEqual(t, "", `<a class="issue-link" href="https://b.example.com/XYZ-1" target="_blank" rel="noopener">XYZ-1</a>`, linkIssues("XYZ-1"))
+}
+
+func TestInvalidIssueLinksAreSkipped(t *testing.T) {
+	setIssueLinks("JIRA-[0-9+ => https://jira.example.com/browse/$0; no url; bug:([0-9]+) => https://bugs.example.com/$1")
+	defer setIssueLinks("")
+
+	if len(issueLinks) != 1 || issueLinks[0].url != "https://bugs.example.com/$1" {
+		t.Errorf("Expected only the valid issue link, got %v", issueLinks)
+	}
+}
+
+func TestTagsAndScenarioHeadingAreLinked(t *testing.T) {
+	readTemplates(templateBasePath)
+	setIssueLinks("JIRA-[0-9]+ => https://jira.example.com/browse/$0")
+	defer setIssueLinks("")
+	buf := new(bytes.Buffer)
+
+	execTemplate("tagsDiv", buf, &scenario{Tags: []string{"JIRA-42", "smoke"}})
+	execTemplate("scenarioHeaderStartDiv", buf, &scenario{Heading: "Fixed in JIRA-7", ExecutionTime: 0})
+
+	for _, want := range []string{
+		`<a class="issue-link" href="https://jira.example.com/browse/JIRA-42" target="_blank" rel="noopener">JIRA-42</a>`,
+		`Fixed in <a class="issue-link" href="https://jira.example.com/browse/JIRA-7" target="_blank" rel="noopener">JIRA-7</a>`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %s in\n%s", want, buf.String())
+		}
+	}
+}
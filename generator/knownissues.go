@@ -0,0 +1,170 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/getgauge/html-report/env"
+	"github.com/getgauge/html-report/logger"
+)
+
+// knownIssue marks a failed scenario as failing for a known reason, e.g. a bug which is not fixed yet.
+type knownIssue struct {
+	Ticket string `json:"Ticket"`
+}
+
+type knownIssueEntry struct {
+	Spec     string `json:"spec"`
+	Scenario string `json:"scenario"`
+	Error    string `json:"error"`
+	Ticket   string `json:"ticket"`
+}
+
+// knownIssueRule matches the failed scenarios of a known issue, by all of the path of their spec, their heading
+// and the error they failed with which are set.
+type knownIssueRule struct {
+	spec     *regexp.Regexp
+	scenario string
+	error    *regexp.Regexp
+	ticket   string
+}
+
+func (r *knownIssueRule) matches(path string, scn *scenario) bool {
+	if r.spec != nil && !r.spec.MatchString(path) {
+		return false
+	}
+	if r.scenario != "" && r.scenario != scn.Heading {
+		return false
+	}
+	if r.error == nil {
+		return true
+	}
+	for _, m := range errorMessages(scn) {
+		if r.error.MatchString(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkKnownIssues marks the failed scenarios of a suite listed in the known issues file set in the environment.
+// They are still reported as failed, but counted apart from the other failures, along with the specs which
+// failed only because of them.
+func MarkKnownIssues(res *SuiteResult, projectRoot string) {
+	f := env.KnownIssuesFile()
+	if f == "" {
+		return
+	}
+	if !filepath.IsAbs(f) {
+		f = filepath.Join(projectRoot, f)
+	}
+	rules, err := readKnownIssues(f)
+	if err != nil {
+		logger.Warnf("Unable to read known issues from %s: %s", f, err.Error())
+		return
+	}
+	markKnownIssues(res, rules, projectRoot)
+}
+
+func readKnownIssues(f string) ([]*knownIssueRule, error) {
+	b, err := os.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	var entries []knownIssueEntry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, err
+	}
+	rules := make([]*knownIssueRule, 0, len(entries))
+	for i, e := range entries {
+		if e.Spec == "" && e.Scenario == "" && e.Error == "" {
+			logger.Warnf("Invalid known issue %d, expected a spec, scenario or error to match", i+1)
+			continue
+		}
+		r := &knownIssueRule{scenario: e.Scenario, ticket: e.Ticket}
+		if e.Spec != "" {
+			r.spec = pathPattern(e.Spec)
+		}
+		if e.Error != "" {
+			if r.error, err = regexp.Compile(e.Error); err != nil {
+				logger.Warnf("Invalid error of known issue %d: %s", i+1, err.Error())
+				continue
+			}
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// markKnownIssues flags the failed scenarios matching a known issue and counts them apart from the other failures.
+// Specs failing only because of known issues no longer count as failed, in the success rate either.
+func markKnownIssues(res *SuiteResult, rules []*knownIssueRule, projectRoot string) {
+	knownSpecs := 0
+	for _, s := range res.SpecResults {
+		path := projectPath(s.FileName, projectRoot)
+		known := 0
+		for _, scn := range s.Scenarios {
+			if scn.ExecutionStatus != fail {
+				continue
+			}
+			for _, r := range rules {
+				if r.matches(path, scn) {
+					scn.KnownIssue = &knownIssue{Ticket: r.ticket}
+					known++
+					break
+				}
+			}
+		}
+		if known == 0 {
+			continue
+		}
+		s.FailedScenarioCount -= known
+		s.KnownIssueScenarioCount += known
+		res.FailedScenarioCount = max(res.FailedScenarioCount-known, 0)
+		res.KnownIssueScenarioCount += known
+		if s.ExecutionStatus == fail && s.FailedScenarioCount == 0 && len(s.Errors) == 0 &&
+			len(s.BeforeSpecHookFailures) == 0 && len(s.AfterSpecHookFailures) == 0 {
+			s.HasOnlyKnownIssues = true
+			res.FailedSpecsCount--
+			res.KnownIssueSpecsCount++
+			knownSpecs++
+		}
+	}
+	if knownSpecs > 0 {
+		res.SuccessRate = getSuccessRate(len(res.SpecResults), res.FailedSpecsCount+res.SkippedSpecsCount)
+	}
+}
+
+// errorMessages returns the messages of the failures of a scenario, of its steps as well as of its hooks.
+func errorMessages(scn *scenario) []string {
+	var messages []string
+	addHook := func(h *hookFailure) {
+		if h != nil {
+			messages = append(messages, h.ErrMsg)
+		}
+	}
+	addHook(scn.BeforeScenarioHookFailure)
+	for _, i := range append(append(append([]item{}, scn.Contexts...), scn.Items...), scn.Teardowns...) {
+		for _, st := range collectSteps(i) {
+			addHook(st.BeforeStepHookFailure)
+			if st.Result != nil && st.Result.ErrorMessage != "" {
+				messages = append(messages, st.Result.ErrorMessage)
+			}
+			addHook(st.AfterStepHookFailure)
+		}
+	}
+	addHook(scn.AfterScenarioHookFailure)
+	return messages
+}
+
+// HasUnknownFailures reports whether a suite failed for other reasons than its known issues.
+func (r *SuiteResult) HasUnknownFailures() bool {
+	return r.FailedSpecsCount > 0 || r.BeforeSuiteHookFailure != nil || r.AfterSuiteHookFailure != nil
+}
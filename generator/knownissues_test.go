@@ -0,0 +1,177 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	helper "github.com/getgauge/html-report/test_helper"
+)
+
+func failedStepItem(message string) item {
+	return item{Kind: stepKind, Step: &step{Result: &result{Status: fail, ErrorMessage: message}}}
+}
+
+func knownIssuesSuite() *SuiteResult {
+	return &SuiteResult{
+		ExecutionStatus:     fail,
+		FailedSpecsCount:    2,
+		PassedSpecsCount:    1,
+		FailedScenarioCount: 3,
+		PassedScenarioCount: 2,
+		SpecResults: []*spec{
+			{SpecHeading: "Checkout", FileName: "/project/specs/checkout.spec", ExecutionStatus: fail, FailedScenarioCount: 2, PassedScenarioCount: 1, Scenarios: []*scenario{
+				{Heading: "Pay by voucher", ExecutionStatus: fail, Items: []item{failedStepItem("expected 10 but was 12")}},
+				{Heading: "Pay by card", ExecutionStatus: fail, Items: []item{failedStepItem("Connection refused: payments:8080")}},
+				{Heading: "Browse", ExecutionStatus: pass},
+			}},
+			{SpecHeading: "Login", FileName: "/project/specs/login.spec", ExecutionStatus: fail, FailedScenarioCount: 1, Scenarios: []*scenario{
+				{Heading: "Login", ExecutionStatus: fail, BeforeScenarioHookFailure: &hookFailure{ErrMsg: "Connection refused: db:5432"}},
+			}},
+			{SpecHeading: "Home", FileName: "/project/specs/home.spec", ExecutionStatus: pass, PassedScenarioCount: 1, Scenarios: []*scenario{
+				{Heading: "Home", ExecutionStatus: pass},
+			}},
+		},
+	}
+}
+
+func TestReadKnownIssuesSkipsInvalidEntries(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "known-issues.json")
+	content := `[
+		{"spec": "specs/checkout.spec", "scenario": "Pay by voucher", "ticket": "PAY-1"},
+		{"error": "Connection refused", "ticket": "OPS-2"},
+		{"ticket": "NOTHING-3"},
+		{"error": "[unclosed", "ticket": "BROKEN-4"}
+	]`
+	if err := os.WriteFile(f, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := readKnownIssues(f)
+
+	if err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+	checkEqual(t, "", 2, len(rules))
+	checkEqual(t, "", "PAY-1", rules[0].ticket)
+	checkEqual(t, "", "OPS-2", rules[1].ticket)
+}
+
+func TestMarkKnownIssuesCountsThemApart(t *testing.T) {
+	res := knownIssuesSuite()
+	rules := []*knownIssueRule{
+		{spec: pathPattern("specs/checkout.spec"), scenario: "Pay by voucher", ticket: "PAY-1"},
+		{error: regexp.MustCompile("Connection refused: db"), ticket: "OPS-2"},
+	}
+
+	markKnownIssues(res, rules, "/project")
+
+	checkout, login := res.SpecResults[0], res.SpecResults[1]
+	checkEqual(t, "", &knownIssue{Ticket: "PAY-1"}, checkout.Scenarios[0].KnownIssue)
+	if checkout.Scenarios[1].KnownIssue != nil {
+		t.Errorf("Expected %s not to be a known issue", checkout.Scenarios[1].Heading)
+	}
+	checkEqual(t, "", 1, checkout.FailedScenarioCount)
+	checkEqual(t, "", 1, checkout.KnownIssueScenarioCount)
+	checkEqual(t, "", false, checkout.HasOnlyKnownIssues)
+	checkEqual(t, "", &knownIssue{Ticket: "OPS-2"}, login.Scenarios[0].KnownIssue)
+	checkEqual(t, "", true, login.HasOnlyKnownIssues)
+	checkEqual(t, "", 1, res.FailedSpecsCount)
+	checkEqual(t, "", 1, res.KnownIssueSpecsCount)
+	checkEqual(t, "", 1, res.FailedScenarioCount)
+	checkEqual(t, "", 2, res.KnownIssueScenarioCount)
+	checkEqual(t, "", true, res.HasUnknownFailures())
+}
+
+func TestSuiteWithOnlyKnownIssuesHasNoUnknownFailures(t *testing.T) {
+	res := knownIssuesSuite()
+	rules := []*knownIssueRule{{spec: pathPattern("specs/"), ticket: "ALL-1"}}
+
+	markKnownIssues(res, rules, "/project")
+
+	checkEqual(t, "", 0, res.FailedSpecsCount)
+	checkEqual(t, "", 2, res.KnownIssueSpecsCount)
+	checkEqual(t, "", false, res.HasUnknownFailures())
+	res.AfterSuiteHookFailure = &hookFailure{ErrMsg: "teardown failed"}
+	checkEqual(t, "", true, res.HasUnknownFailures())
+}
+
+func TestKnownIssuesAreRendered(t *testing.T) {
+	readTemplates(templateBasePath)
+	setIssueLinks("PAY-[0-9]+ => https://jira.example.com/browse/$0")
+	defer setIssueLinks("")
+	res := knownIssuesSuite()
+	markKnownIssues(res, []*knownIssueRule{
+		{scenario: "Pay by voucher", ticket: "PAY-1"},
+		{scenario: "Login", ticket: "OPS-2"},
+	}, "/project")
+	overview := new(bytes.Buffer)
+	header := new(bytes.Buffer)
+
+	execTemplate("reportOverviewTag", overview, toOverview(res, ""))
+	execTemplate("scenarioHeaderStartDiv", header, res.SpecResults[0].Scenarios[0])
+
+	for _, want := range []string{
+		`data-results="1,1,0,1"`,
+		`<path class="shadow known" data-status="known">`,
+		`<div class="known spec-filter" role="button" tabindex="0" aria-pressed="false" data-status="known" title="Filter specs with known issues"><span class="value">1</span>`,
+		`<div class="known scenario-stats" data-status="known"><span class="value">2</span>`,
+	} {
+		if got := helper.RemoveNewline(overview.String()); !strings.Contains(got, want) {
+			t.Errorf("Expected %s in\n%s", want, got)
+		}
+	}
+	want := `<span class="scenario-known-issue">Known issue <a class="issue-link" href="https://jira.example.com/browse/PAY-1" target="_blank" rel="noopener">PAY-1</a></span>`
+	if got := helper.RemoveNewline(header.String()); !strings.Contains(got, want) {
+		t.Errorf("Expected %s in\n%s", want, got)
+	}
+}
+
+func TestKnownIssuesAreNotFailuresOfOwners(t *testing.T) {
+	oldProjectRoot := projectRoot
+	projectRoot = "/project"
+	defer func() { projectRoot = oldProjectRoot }()
+	res := knownIssuesSuite()
+	assignOwners(res, parseOwnerRules("* team-core"), "/project")
+	markKnownIssues(res, []*knownIssueRule{{scenario: "Pay by voucher"}, {scenario: "Login"}}, "/project")
+
+	got := toOwnerFailures(res)
+
+	checkEqual(t, "", 1, len(got))
+	checkEqual(t, "", []*ownedFailure{{Name: "Pay by card", Spec: "Checkout", ReportFile: "specs/checkout.html"}}, got[0].Failures)
+}
+
+func TestNestedSuiteResultCountsKnownIssues(t *testing.T) {
+	oldProjectRoot := projectRoot
+	projectRoot = "/project"
+	defer func() { projectRoot = oldProjectRoot }()
+	res := knownIssuesSuite()
+	markKnownIssues(res, []*knownIssueRule{{scenario: "Login"}}, "/project")
+
+	got := toNestedSuiteResult("specs", res)
+
+	checkEqual(t, "", 1, got.FailedSpecsCount)
+	checkEqual(t, "", 1, got.KnownIssueSpecsCount)
+	checkEqual(t, "", 1, got.KnownIssueScenarioCount)
+}
+
+func TestSuccessRateLeavesOutSpecsWithOnlyKnownIssues(t *testing.T) {
+	oldProjectRoot := projectRoot
+	projectRoot = "/project"
+	defer func() { projectRoot = oldProjectRoot }()
+	res := knownIssuesSuite()
+	res.SuccessRate = 33
+
+	markKnownIssues(res, []*knownIssueRule{{scenario: "Login"}}, "/project")
+
+	checkEqual(t, "", float32(66), res.SuccessRate)
+	checkEqual(t, "", res.SuccessRate, toNestedSuiteResult("specs", res).SuccessRate)
+}
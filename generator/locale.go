@@ -0,0 +1,74 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"sort"
+
+	"github.com/getgauge/html-report/logger"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const defaultLocale = "en"
+
+var (
+	// translations are the messages of every locale of the theme, read along with its templates
+	translations map[string]map[string]string
+	messages     map[string]string
+	// reportLanguage is the language the text of the report is in, which can differ from the locale
+	// numbers are formatted for when the theme has no translations for it
+	reportLanguage = defaultLocale
+	printer        = message.NewPrinter(language.English)
+)
+
+// setLocale picks the translations closest to the locale, e.g. de for de-AT. The report stays in English
+// if the theme has none, but numbers are still formatted for the locale.
+func setLocale(locale string) {
+	messages, reportLanguage, printer = nil, defaultLocale, message.NewPrinter(language.English)
+	if locale == "" {
+		return
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		logger.Warnf("Unknown locale %s, using %s", locale, defaultLocale)
+		return
+	}
+	printer = message.NewPrinter(tag)
+	names := []string{defaultLocale}
+	for name := range translations {
+		if name != defaultLocale {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	tags := make([]language.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, language.Make(name))
+	}
+	_, i, confidence := language.NewMatcher(tags).Match(tag)
+	if confidence == language.No {
+		logger.Debugf("[Warning] The theme has no translations for locale %s, the report is in %s", locale, defaultLocale)
+		return
+	}
+	messages, reportLanguage = translations[names[i]], names[i]
+}
+
+// translate returns the translation of an English text of the templates, or the text itself if there is none.
+// Arguments are formatted into the translation like fmt.Sprintf does, with numbers formatted for the locale.
+func translate(text string, args ...interface{}) string {
+	if m, ok := messages[text]; ok {
+		text = m
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return printer.Sprintf(text, args...)
+}
+
+func formatNumber(n interface{}) string {
+	return printer.Sprint(n)
+}
@@ -0,0 +1,78 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSetLocaleMatchesClosestTranslations(t *testing.T) {
+	readTemplates(templateBasePath)
+	defer setLocale("")
+	tests := []struct {
+		locale   string
+		language string
+		rate     string
+	}{
+		{"", "en", "Success Rate"},
+		{"de", "de", "Erfolgsquote"},
+		{"de-AT", "de", "Erfolgsquote"},
+		{"ja_JP", "ja", "成功率"},
+		{"fr", "en", "Success Rate"},
+		{"not a locale", "en", "Success Rate"},
+	}
+	for _, test := range tests {
+		setLocale(test.locale)
+		checkEqual(t, test.locale, test.language, reportLanguage)
+		checkEqual(t, test.locale, test.rate, translate("Success Rate"))
+	}
+}
+
+func TestTranslateFormatsArgumentsForLocale(t *testing.T) {
+	readTemplates(templateBasePath)
+	defer setLocale("")
+
+	setLocale("de")
+	checkEqual(t, "", "12.345-mal wiederholt", translate("Retried %d times", 12345))
+	checkEqual(t, "", "66,5 %", translate("%v%%", 66.5))
+	checkEqual(t, "", "1.234", formatNumber(1234))
+
+	setLocale("fr")
+	checkEqual(t, "", "Retried 12\u00a0345 times", translate("Retried %d times", 12345))
+
+	setLocale("ja")
+	checkEqual(t, "", "仕様: 4 件中 失敗 1、成功 2、スキップ 1", translate("Specifications: %d failed, %d passed, %d skipped of %d", 1, 2, 1, 4))
+}
+
+func TestGeneratedOnIsFormattedForLocale(t *testing.T) {
+	readTemplates(templateBasePath)
+	defer setLocale("")
+	setLocale("de")
+
+	got := toFormattedLocalTime("2017-01-02T15:04:05Z", "")
+
+	if !strings.Contains(got, "01.2017, ") {
+		t.Errorf("Expected a German date, got %s", got)
+	}
+}
+
+func TestReportDetailsAreTranslated(t *testing.T) {
+	readTemplates(templateBasePath)
+	defer setLocale("")
+	setLocale("de-DE")
+	buf := new(bytes.Buffer)
+
+	execTemplate("htmlPageStartTag", buf, toOverview(suiteRes1, ""))
+	execTemplate("reportOverviewTag", buf, toOverview(suiteRes1, ""))
+
+	for _, want := range []string{`<html lang="de"`, "<title>Gauge-Testergebnisse</title>", "Erfolgsquote", "Gesamtzeit"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %s in\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/html-report/env"
+	"github.com/getgauge/html-report/logger"
+)
+
+type metadataEntry struct {
+	Key   string
+	Value string
+	Link  bool
+}
+
+// ReadMetadata collects the metadata shown along with the details of the report, like the build number or
+// the commit under test. It is read from the JSON file set in the environment and from the prefixed
+// environment properties, which take precedence. It describes the current execution, so it is not read when a report
+// is regenerated from a saved result.
+func ReadMetadata(projectRoot string) map[string]string {
+	metadata := make(map[string]string)
+	if f := env.MetadataFile(); f != "" {
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(projectRoot, f)
+		}
+		m, err := readMetadataFile(f)
+		if err != nil {
+			logger.Warnf("Unable to read report metadata from %s: %s", f, err.Error())
+		}
+		for k, v := range m {
+			metadata[k] = v
+		}
+	}
+	for k, v := range env.MetadataProperties() {
+		metadata[k] = v
+	}
+	return metadata
+}
+
+func readMetadataFile(f string) (map[string]string, error) {
+	b, err := os.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	// Numbers are kept as written, e.g. a build number of 1234567 rather than 1.234567e+06
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var values map[string]interface{}
+	if err := d.Decode(&values); err != nil {
+		return nil, err
+	}
+	metadata := make(map[string]string, len(values))
+	for k, v := range values {
+		if v == nil {
+			continue
+		}
+		metadata[k] = fmt.Sprint(v)
+	}
+	return metadata, nil
+}
+
+func toMetadataEntries(metadata map[string]string) []metadataEntry {
+	entries := make([]metadataEntry, 0, len(metadata))
+	for k, v := range metadata {
+		link := strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://")
+		entries = append(entries, metadataEntry{Key: k, Value: v, Link: link})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
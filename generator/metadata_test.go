@@ -0,0 +1,66 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	helper "github.com/getgauge/html-report/test_helper"
+)
+
+func TestReadMetadataFromFileAndProperties(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"commit": "4f2a9c1", "build number": 1234567, "ratio": 0.25, "browser": "firefox", "unset": null}`
+	if err := os.WriteFile(filepath.Join(dir, "metadata.json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("html_report_metadata_file", "metadata.json")
+	t.Setenv("html_report_metadata_browser", "chrome 126")
+
+	got := ReadMetadata(dir)
+
+	want := map[string]string{"commit": "4f2a9c1", "build number": "1234567", "ratio": "0.25", "browser": "chrome 126"}
+	checkEqual(t, "", want, got)
+}
+
+func TestReadMetadataIgnoresInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "metadata.json"), []byte(`["not", "an", "object"]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("html_report_metadata_file", filepath.Join(dir, "metadata.json"))
+	t.Setenv("html_report_metadata_branch", "main")
+
+	got := ReadMetadata("")
+
+	checkEqual(t, "", map[string]string{"branch": "main"}, got)
+}
+
+func TestMetadataIsRenderedInReportDetails(t *testing.T) {
+	readTemplates(templateBasePath)
+	res := ToSuiteResult("", newProtoSuiteRes(false, 0, 0, 100, nil, nil, passSpecRes1))
+	res.Metadata = map[string]string{"target URL": "https://example.com/?a=1&b=2", "branch": "<main>"}
+	buf := new(bytes.Buffer)
+
+	execTemplate("reportDetails", buf, toOverview(res, ""))
+
+	got := buf.String()
+	for _, want := range []string{
+		`<label>branch </label><span>&lt;main&gt;</span>`,
+		`<label>target URL </label><a href="https://example.com/?a=1&amp;b=2" target="_blank" rel="noopener">https://example.com/?a=1&amp;b=2</a>`,
+	} {
+		if !strings.Contains(helper.RemoveNewline(got), want) {
+			t.Errorf("Expected %s in\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "branch") > strings.Index(got, "target URL") {
+		t.Errorf("Expected metadata sorted by key in\n%s", got)
+	}
+}
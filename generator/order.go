@@ -0,0 +1,125 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getgauge/html-report/logger"
+)
+
+// The orders in which specs and scenarios can be listed. Status lists the failures first, then the known issues,
+// the skipped and the passed ones. Duration lists the slowest first.
+const (
+	statusOrder    = "status"
+	sourceOrder    = "source"
+	executionOrder = "execution"
+	nameOrder      = "name"
+	durationOrder  = "duration"
+)
+
+var sortOrders = []string{statusOrder, sourceOrder, executionOrder, nameOrder, durationOrder}
+
+var (
+	specsOrder     = statusOrder
+	scenariosOrder = statusOrder
+)
+
+func setSortOrders(specs, scenarios string) {
+	specsOrder = toSortOrder(specs, "specs")
+	scenariosOrder = toSortOrder(scenarios, "scenarios")
+}
+
+func toSortOrder(order, of string) string {
+	if order == "" {
+		return statusOrder
+	}
+	for _, o := range sortOrders {
+		if o == order {
+			return o
+		}
+	}
+	logger.Warnf("Unknown order of %s %s, using %s", of, order, statusOrder)
+	return statusOrder
+}
+
+// sortSpecsMeta orders the specs of a sidebar. Source order is the order of their files.
+func sortSpecsMeta(specs []*specsMeta, order string) {
+	switch order {
+	case statusOrder:
+		sort.Stable(byStatus(specs))
+	case sourceOrder:
+		sort.SliceStable(specs, func(i, j int) bool { return specs[i].SourceIndex < specs[j].SourceIndex })
+	case executionOrder:
+		sort.SliceStable(specs, func(i, j int) bool { return specs[i].ExecutionIndex < specs[j].ExecutionIndex })
+	case nameOrder:
+		sort.SliceStable(specs, func(i, j int) bool {
+			return strings.ToLower(specs[i].SpecName) < strings.ToLower(specs[j].SpecName)
+		})
+	case durationOrder:
+		sort.SliceStable(specs, func(i, j int) bool { return specs[i].ExecutionTime > specs[j].ExecutionTime })
+	}
+}
+
+// sourceIndexes returns the position of each spec of a suite in the order of their files.
+func sourceIndexes(specs []*spec) map[*spec]int {
+	sorted := append([]*spec{}, specs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].FileName < sorted[j].FileName })
+	indexes := make(map[*spec]int, len(sorted))
+	for i, s := range sorted {
+		indexes[s] = i
+	}
+	return indexes
+}
+
+// sortScenarios orders the scenarios of a spec. Source order is the order of their headings in the spec file,
+// the rows of a table driven scenario staying in the order they were executed in.
+func sortScenarios(scenarios []*scenario, order string) {
+	byExecution := func(i, j int) bool { return scenarios[i].ExecutionIndex < scenarios[j].ExecutionIndex }
+	switch order {
+	case statusOrder:
+		sort.SliceStable(scenarios, byExecution)
+		sort.Stable(bySceStatus(scenarios))
+	case sourceOrder:
+		sort.SliceStable(scenarios, func(i, j int) bool {
+			if li, lj := scenarios[i].SourceLine(), scenarios[j].SourceLine(); li != lj {
+				return li < lj
+			}
+			return byExecution(i, j)
+		})
+	case executionOrder:
+		sort.SliceStable(scenarios, byExecution)
+	case nameOrder:
+		sort.SliceStable(scenarios, func(i, j int) bool {
+			if ni, nj := strings.ToLower(scenarios[i].Heading), strings.ToLower(scenarios[j].Heading); ni != nj {
+				return ni < nj
+			}
+			return byExecution(i, j)
+		})
+	case durationOrder:
+		sort.SliceStable(scenarios, func(i, j int) bool {
+			if scenarios[i].ExecutionTime != scenarios[j].ExecutionTime {
+				return scenarios[i].ExecutionTime > scenarios[j].ExecutionTime
+			}
+			return byExecution(i, j)
+		})
+	}
+}
+
+// SourceLine returns the line of the heading of a scenario, 0 if it is not known.
+func (s *scenario) SourceLine() int {
+	if s.Source == nil {
+		return 0
+	}
+	return s.Source.Line
+}
+
+func sortAllScenarios(res *SuiteResult) {
+	for _, s := range res.SpecResults {
+		sortScenarios(s.Scenarios, scenariosOrder)
+	}
+}
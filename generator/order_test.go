@@ -0,0 +1,86 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"testing"
+)
+
+func orderScenarios() []*scenario {
+	return []*scenario{
+		{Heading: "Pay", ExecutionStatus: pass, ExecutionTime: 300, ExecutionIndex: 2, Source: &source{Line: 30}},
+		{Heading: "browse", ExecutionStatus: fail, ExecutionTime: 100, ExecutionIndex: 1, Source: &source{Line: 20}},
+		{Heading: "Login", ExecutionStatus: skip, ExecutionTime: 200, ExecutionIndex: 0, Source: &source{Line: 10}},
+		{Heading: "Checkout", ExecutionStatus: fail, ExecutionTime: 300, ExecutionIndex: 3, Source: &source{Line: 5}},
+	}
+}
+
+func headings(scenarios []*scenario) []string {
+	var h []string
+	for _, s := range scenarios {
+		h = append(h, s.Heading)
+	}
+	return h
+}
+
+func TestSortScenarios(t *testing.T) {
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{statusOrder, []string{"browse", "Checkout", "Login", "Pay"}},
+		{sourceOrder, []string{"Checkout", "Login", "browse", "Pay"}},
+		{executionOrder, []string{"Login", "browse", "Pay", "Checkout"}},
+		{nameOrder, []string{"browse", "Checkout", "Login", "Pay"}},
+		{durationOrder, []string{"Pay", "Checkout", "Login", "browse"}},
+	}
+	for _, test := range tests {
+		scenarios := orderScenarios()
+
+		sortScenarios(scenarios, test.order)
+
+		checkEqual(t, test.order, test.want, headings(scenarios))
+	}
+}
+
+func TestSortScenariosInSourceOrderKeepsRowsInExecutionOrder(t *testing.T) {
+	scenarios := []*scenario{
+		{Heading: "Row 2", ExecutionStatus: fail, ExecutionIndex: 1, Source: &source{Line: 10}},
+		{Heading: "Row 1", ExecutionStatus: pass, ExecutionIndex: 0, Source: &source{Line: 10}},
+		{Heading: "First", ExecutionStatus: pass, ExecutionIndex: 2, Source: &source{Line: 3}},
+	}
+
+	sortScenarios(scenarios, sourceOrder)
+
+	checkEqual(t, "", []string{"First", "Row 1", "Row 2"}, headings(scenarios))
+}
+
+func TestToSidebarUsesSpecsOrder(t *testing.T) {
+	setSortOrders("source", "")
+	defer setSortOrders("", "")
+
+	got := toSidebar(&SuiteResult{SpecResults: []*spec{
+		{SpecHeading: "B", FileName: "b.spec", ExecutionStatus: pass},
+		{SpecHeading: "C", FileName: "c.spec", ExecutionStatus: fail},
+		{SpecHeading: "A", FileName: "a.spec", ExecutionStatus: pass},
+	}}, "")
+
+	checkEqual(t, "", sourceOrder, got.Order)
+	var names []string
+	for _, s := range got.Specs {
+		names = append(names, s.SpecName)
+	}
+	checkEqual(t, "", []string{"A", "B", "C"}, names)
+	checkEqual(t, "", 2, got.Specs[0].ExecutionIndex)
+}
+
+func TestUnknownSortOrderFallsBackToStatus(t *testing.T) {
+	setSortOrders("random", "Name")
+	defer setSortOrders("", "")
+
+	checkEqual(t, "", statusOrder, specsOrder)
+	checkEqual(t, "", statusOrder, scenariosOrder)
+}
@@ -0,0 +1,218 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/html-report/env"
+	"github.com/getgauge/html-report/logger"
+)
+
+const tagRulePrefix = "tag:"
+
+// ownerRule assigns its owners to the specs whose path matches a pattern, or to the scenarios carrying a tag.
+type ownerRule struct {
+	path   *regexp.Regexp
+	tag    string
+	owners []string
+}
+
+func (r *ownerRule) matches(path string, tags []string) bool {
+	if r.path != nil {
+		return r.path.MatchString(path)
+	}
+	for _, t := range tags {
+		if t == r.tag {
+			return true
+		}
+	}
+	return false
+}
+
+type ownedFailure struct {
+	Name       string
+	Spec       string
+	ReportFile string
+}
+
+type ownerFailures struct {
+	// Owner is empty for the failures nobody owns
+	Owner    string
+	Failures []*ownedFailure
+}
+
+// AssignOwners resolves the owners of the specs and scenarios of a suite from the ownership file set in the
+// environment. As in a CODEOWNERS file, the last rule matching a spec or scenario decides its owners.
+func AssignOwners(res *SuiteResult, projectRoot string) {
+	f := env.OwnersFile()
+	if f == "" {
+		return
+	}
+	if !filepath.IsAbs(f) {
+		f = filepath.Join(projectRoot, f)
+	}
+	b, err := os.ReadFile(f)
+	if err != nil {
+		logger.Warnf("Unable to read owners from %s: %s", f, err.Error())
+		return
+	}
+	assignOwners(res, parseOwnerRules(string(b)), projectRoot)
+}
+
+// parseOwnerRules reads the lines of an ownership file, each a pattern followed by the owners of what it matches.
+// The pattern is either a path or `tag:<tag>`. A pattern without owners leaves what it matches unowned.
+func parseOwnerRules(content string) []*ownerRule {
+	var rules []*ownerRule
+	for i, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		r := &ownerRule{owners: fields[1:]}
+		if tag, ok := strings.CutPrefix(fields[0], tagRulePrefix); ok {
+			if tag == "" {
+				logger.Warnf("Invalid owners rule on line %d, expected a tag after %s", i+1, tagRulePrefix)
+				continue
+			}
+			r.tag = tag
+		} else {
+			r.path = pathPattern(fields[0])
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// pathPattern translates a path pattern of an ownership file, as in a .gitignore file: a pattern without a slash
+// matches a file or directory at any depth, any other is relative to the project root. A directory matches
+// everything in it, `*` matches any part of a name and `**` any number of directories.
+func pathPattern(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	if !strings.Contains(strings.TrimSuffix(glob, "/"), "/") {
+		b.WriteString("(?:.*/)?")
+	}
+	glob = strings.Trim(glob, "/")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			b.WriteString("[^/]*")
+		case glob[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("(?:/.*)?$")
+	return regexp.MustCompile(b.String())
+}
+
+func assignOwners(res *SuiteResult, rules []*ownerRule, projectRoot string) {
+	for _, s := range res.SpecResults {
+		path := projectPath(s.FileName, projectRoot)
+		s.Owners = matchOwners(rules, path, s.Tags)
+		for _, scn := range s.Scenarios {
+			scn.Owners = matchOwners(rules, path, append(append([]string{}, s.Tags...), scn.Tags...))
+		}
+	}
+}
+
+// projectPath returns the path of a spec file relative to the project, with forward slashes as in the patterns
+// of the files configuring the report.
+func projectPath(fileName, projectRoot string) string {
+	if rel, err := filepath.Rel(projectRoot, fileName); err == nil && projectRoot != "" {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(fileName)
+}
+
+func matchOwners(rules []*ownerRule, path string, tags []string) []string {
+	var owners []string
+	for _, r := range rules {
+		if r.matches(path, tags) {
+			owners = r.owners
+		}
+	}
+	return owners
+}
+
+func hasOwners(res *SuiteResult) bool {
+	for _, s := range res.SpecResults {
+		if len(s.Owners) > 0 {
+			return true
+		}
+		for _, scn := range s.Scenarios {
+			if len(scn.Owners) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// toOwnerFailures groups the failed scenarios of a suite, other than its known issues, by their owners, with the owners of most failures first
+// and the unowned failures last. Specs which failed without a failing scenario, e.g. in a hook or to parse, are
+// grouped by the owners of the spec. It is empty if nothing in the suite has an owner.
+func toOwnerFailures(res *SuiteResult) []*ownerFailures {
+	if !hasOwners(res) {
+		return nil
+	}
+	basePath := getFilePathBasedOnSpecLocation("", res.BasePath)
+	groups := make(map[string]*ownerFailures)
+	add := func(owners []string, f *ownedFailure) {
+		if len(owners) == 0 {
+			owners = []string{""}
+		}
+		for _, o := range owners {
+			if _, ok := groups[o]; !ok {
+				groups[o] = &ownerFailures{Owner: o}
+			}
+			groups[o].Failures = append(groups[o].Failures, f)
+		}
+	}
+	for _, s := range res.SpecResults {
+		if s.ExecutionStatus != fail || s.HasOnlyKnownIssues {
+			continue
+		}
+		reportFile := toHTMLFileName(s.FileName, basePath)
+		failedScenarios := false
+		for _, scn := range s.Scenarios {
+			if scn.ExecutionStatus == fail && scn.KnownIssue == nil {
+				failedScenarios = true
+				add(scn.Owners, &ownedFailure{Name: scn.Heading, Spec: s.SpecHeading, ReportFile: reportFile})
+			}
+		}
+		if !failedScenarios {
+			add(s.Owners, &ownedFailure{Name: s.SpecHeading, ReportFile: reportFile})
+		}
+	}
+	failures := make([]*ownerFailures, 0, len(groups))
+	for _, g := range groups {
+		failures = append(failures, g)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		a, b := failures[i], failures[j]
+		if (a.Owner == "") != (b.Owner == "") {
+			return b.Owner == ""
+		}
+		if len(a.Failures) != len(b.Failures) {
+			return len(a.Failures) > len(b.Failures)
+		}
+		return a.Owner < b.Owner
+	})
+	return failures
+}
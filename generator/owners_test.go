@@ -0,0 +1,146 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	helper "github.com/getgauge/html-report/test_helper"
+)
+
+func TestPathPatternMatchesLikeGitignore(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*", "specs/login.spec", true},
+		{"login.spec", "specs/auth/login.spec", true},
+		{"*.spec", "specs/auth/login.spec", true},
+		{"auth", "specs/auth/login.spec", true},
+		{"auth/", "specs/auth/login.spec", true},
+		{"specs/auth", "specs/auth/login.spec", true},
+		{"/specs/auth/", "specs/auth/login.spec", true},
+		{"auth", "specs/authentication/login.spec", false},
+		{"specs/auth", "other/specs/auth/login.spec", false},
+		{"specs/*.spec", "specs/auth/login.spec", false},
+		{"specs/**/login.spec", "specs/auth/login.spec", true},
+		{"specs/**/login.spec", "specs/login.spec", true},
+		{"specs/**", "specs/auth/login.spec", true},
+		{"specs/log?n.spec", "specs/login.spec", true},
+		{"specs/a+b.spec", "specs/aab.spec", false},
+	}
+	for _, test := range tests {
+		if got := pathPattern(test.pattern).MatchString(test.path); got != test.want {
+			t.Errorf("Expected %s matching %s to be %v", test.pattern, test.path, test.want)
+		}
+	}
+}
+
+func TestAssignOwnersUsesLastMatchingRule(t *testing.T) {
+	rules := parseOwnerRules(`
+# Default owners
+*                      team-qa
+specs/checkout/        team-payments  team-qa
+tag:login              team-identity
+specs/checkout/wip/
+tag:
+`)
+	res := &SuiteResult{SpecResults: []*spec{
+		{FileName: "/project/specs/checkout/pay.spec", Scenarios: []*scenario{
+			{Heading: "Pay"},
+			{Heading: "Pay after login", Tags: []string{"login"}},
+		}},
+		{FileName: "/project/specs/home.spec", Tags: []string{"login"}, Scenarios: []*scenario{{Heading: "Home"}}},
+		{FileName: "/project/specs/checkout/wip/new.spec", Scenarios: []*scenario{{Heading: "New"}}},
+	}}
+
+	assignOwners(res, rules, "/project")
+
+	checkEqual(t, "", 4, len(rules))
+	pay, home, wip := res.SpecResults[0], res.SpecResults[1], res.SpecResults[2]
+	checkEqual(t, "", []string{"team-payments", "team-qa"}, pay.Owners)
+	checkEqual(t, "", []string{"team-payments", "team-qa"}, pay.Scenarios[0].Owners)
+	checkEqual(t, "", []string{"team-identity"}, pay.Scenarios[1].Owners)
+	checkEqual(t, "", []string{"team-identity"}, home.Owners)
+	checkEqual(t, "", []string{"team-identity"}, home.Scenarios[0].Owners)
+	checkEqual(t, "", 0, len(wip.Owners))
+}
+
+func TestAssignOwnersReadsFileRelativeToProject(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "OWNERS"), []byte("specs/ team-core\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("html_report_owners_file", "OWNERS")
+	res := &SuiteResult{SpecResults: []*spec{{FileName: filepath.Join(dir, "specs", "a.spec")}}}
+
+	AssignOwners(res, dir)
+
+	checkEqual(t, "", []string{"team-core"}, res.SpecResults[0].Owners)
+}
+
+func TestToOwnerFailuresGroupsFailuresByOwner(t *testing.T) {
+	res := &SuiteResult{SpecResults: []*spec{
+		{SpecHeading: "Checkout", FileName: "checkout.spec", ExecutionStatus: fail, Owners: []string{"payments"}, Scenarios: []*scenario{
+			{Heading: "Pay", ExecutionStatus: fail, Owners: []string{"payments"}},
+			{Heading: "Refund", ExecutionStatus: fail, Owners: []string{"payments", "support"}},
+			{Heading: "Browse", ExecutionStatus: pass, Owners: []string{"catalog"}},
+		}},
+		{SpecHeading: "Broken", FileName: "broken.spec", ExecutionStatus: fail, Owners: []string{"support"}},
+		{SpecHeading: "Legacy", FileName: "legacy.spec", ExecutionStatus: fail, Scenarios: []*scenario{
+			{Heading: "Old", ExecutionStatus: fail},
+		}},
+		{SpecHeading: "Passing", FileName: "passing.spec", ExecutionStatus: pass, Owners: []string{"catalog"}},
+	}}
+
+	got := toOwnerFailures(res)
+
+	checkEqual(t, "", 3, len(got))
+	checkEqual(t, "", "payments", got[0].Owner)
+	checkEqual(t, "", []*ownedFailure{
+		{Name: "Pay", Spec: "Checkout", ReportFile: "checkout.html"},
+		{Name: "Refund", Spec: "Checkout", ReportFile: "checkout.html"},
+	}, got[0].Failures)
+	checkEqual(t, "", "support", got[1].Owner)
+	checkEqual(t, "", &ownedFailure{Name: "Broken", ReportFile: "broken.html"}, got[1].Failures[1])
+	checkEqual(t, "", "", got[2].Owner)
+	checkEqual(t, "", "Old", got[2].Failures[0].Name)
+}
+
+func TestToOwnerFailuresIsEmptyWithoutOwners(t *testing.T) {
+	res := ToSuiteResult("", newProtoSuiteRes(true, 1, 0, 50, nil, nil, failSpecResWithStepFailure))
+
+	if got := toOwnerFailures(res); got != nil {
+		t.Errorf("Expected no failures by owner, got %v", got)
+	}
+}
+
+func TestOwnerFailuresAreRenderedOnIndexPage(t *testing.T) {
+	readTemplates(templateBasePath)
+	res := ToSuiteResult("", newProtoSuiteRes(true, 1, 0, 50, nil, nil, failSpecResWithStepFailure))
+	assignOwners(res, parseOwnerRules("* <team>"), "")
+	buf := new(bytes.Buffer)
+
+	execTemplate("indexPage", buf, res)
+
+	want := `<h4>&lt;team&gt; <span class="failure-count">1</span></h4>`
+	if got := helper.RemoveNewline(buf.String()); !strings.Contains(got, want) {
+		t.Errorf("Expected %s in\n%s", want, got)
+	}
+}
+
+func TestSearchIndexHasOwners(t *testing.T) {
+	index := NewSearchIndex()
+
+	index.add(&spec{FileName: "a.spec", Owners: []string{"core"}, Scenarios: []*scenario{{Owners: []string{"core", "ui"}}}})
+
+	checkEqual(t, "", map[string][]string{"core": {"a.html"}, "ui": {"a.html"}}, index.Owners)
+}
@@ -0,0 +1,162 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	performancePage     = "performance.html"
+	performanceTemplate = "performancePage"
+	performanceTopCount = 20
+)
+
+type timing struct {
+	Name       string
+	Spec       string
+	ReportFile string
+	Status     status
+	Count      int
+	Duration   duration
+	Average    duration
+}
+
+type performance struct {
+	Specs               []*timing
+	Scenarios           []*timing
+	Steps               []*timing
+	StepImplementations []*timing
+	Hooks               []*timing
+	TotalHookTime       duration
+}
+
+// toPerformance aggregates the execution times of a suite into the slowest specs, scenarios, steps and step implementations.
+// Gauge does not report the duration of hooks, so the time a spec or scenario spent outside of its children is reported instead.
+func toPerformance(res *SuiteResult) *performance {
+	p := &performance{}
+	impls := make(map[string]*timing)
+	var hookTime duration
+	for _, s := range res.SpecResults {
+		reportFile := toHTMLFileName(s.FileName, projectRoot)
+		p.Specs = append(p.Specs, &timing{Name: s.SpecHeading, ReportFile: reportFile, Status: s.ExecutionStatus, Duration: s.ExecutionTime})
+		var scenariosTime duration
+		for _, scn := range s.Scenarios {
+			scenariosTime += scn.ExecutionTime
+			p.Scenarios = append(p.Scenarios, &timing{Name: scn.Heading, Spec: s.SpecHeading, ReportFile: reportFile, Status: scn.ExecutionStatus, Duration: scn.ExecutionTime})
+			steps := make([]*step, 0)
+			for _, i := range append(append(append([]item{}, scn.Contexts...), scn.Items...), scn.Teardowns...) {
+				steps = append(steps, collectSteps(i)...)
+			}
+			var stepsTime duration
+			for _, st := range steps {
+				d := st.Result.ExecutionTime
+				stepsTime += d
+				p.Steps = append(p.Steps, &timing{Name: stepText(st, true), Spec: s.SpecHeading, ReportFile: reportFile, Status: st.Result.Status, Duration: d})
+				key := stepText(st, false)
+				if _, ok := impls[key]; !ok {
+					impls[key] = &timing{Name: key}
+				}
+				impls[key].Count++
+				impls[key].Duration += d
+			}
+			if t := scn.ExecutionTime - stepsTime; t > 0 {
+				hookTime += t
+				p.Hooks = append(p.Hooks, &timing{Name: scn.Heading, Spec: s.SpecHeading, ReportFile: reportFile, Status: scn.ExecutionStatus, Duration: t})
+			}
+		}
+		if t := s.ExecutionTime - scenariosTime; t > 0 {
+			hookTime += t
+			p.Hooks = append(p.Hooks, &timing{Name: s.SpecHeading, ReportFile: reportFile, Status: s.ExecutionStatus, Duration: t})
+		}
+	}
+	for _, t := range impls {
+		p.StepImplementations = append(p.StepImplementations, t)
+	}
+	p.Specs = slowest(p.Specs)
+	p.Scenarios = slowest(p.Scenarios)
+	p.Steps = slowest(p.Steps)
+	p.StepImplementations = slowest(p.StepImplementations)
+	p.Hooks = slowest(p.Hooks)
+	p.TotalHookTime = hookTime
+	return p
+}
+
+// collectSteps returns the steps of an item. Concepts are expanded into their steps, since the time of a concept
+// is the sum of the time of its steps.
+func collectSteps(i item) []*step {
+	switch i.Kind {
+	case stepKind:
+		return []*step{i.Step}
+	case conceptKind:
+		steps := make([]*step, 0)
+		for _, ci := range i.Concept.Items {
+			steps = append(steps, collectSteps(ci)...)
+		}
+		return steps
+	}
+	return nil
+}
+
+// stepText returns the text of a step. Without values, parameters are replaced by {} so that all usages of
+// a step implementation yield the same text.
+func stepText(s *step, withValues bool) string {
+	var b strings.Builder
+	for _, f := range s.Fragments {
+		if f.FragmentKind == textFragmentKind {
+			b.WriteString(f.Text)
+			continue
+		}
+		if !withValues {
+			b.WriteString("{}")
+			continue
+		}
+		switch f.FragmentKind {
+		case staticFragmentKind, dynamicFragmentKind:
+			b.WriteString(`"` + f.Text + `"`)
+		case tableFragmentKind:
+			b.WriteString("<table>")
+		default:
+			b.WriteString("<" + f.Name + ">")
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func slowest(timings []*timing) []*timing {
+	sort.SliceStable(timings, func(i, j int) bool { return timings[i].Duration > timings[j].Duration })
+	if len(timings) > performanceTopCount {
+		timings = timings[:performanceTopCount]
+	}
+	for _, t := range timings {
+		if t.Count > 0 {
+			t.Average = t.Duration / duration(t.Count)
+		}
+	}
+	return timings
+}
+
+func generatePerformancePage(res *SuiteResult, reportsDir string) error {
+	if parsedTemplates.Lookup(performanceTemplate) == nil {
+		return nil
+	}
+	p := filepath.Join(reportsDir, performancePage)
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer func(f *os.File) {
+		if err := f.Close(); err != nil {
+			return
+		}
+	}(f)
+	execTemplate(performanceTemplate, f, res)
+	addHTMLFile(p)
+	return nil
+}
@@ -0,0 +1,81 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func newTimedStep(text, param string, d duration) item {
+	return item{Kind: stepKind, Step: &step{
+		Fragments: []*fragment{
+			{FragmentKind: textFragmentKind, Text: text + " "},
+			{FragmentKind: staticFragmentKind, Text: param},
+		},
+		Result: &result{Status: pass, ExecutionTime: d},
+	}}
+}
+
+func TestStepText(t *testing.T) {
+	s := newTimedStep("Open page", "home", 0).Step
+
+	checkEqual(t, "", `Open page "home"`, stepText(s, true))
+	checkEqual(t, "", "Open page {}", stepText(s, false))
+}
+
+func TestToPerformance(t *testing.T) {
+	login := &scenario{
+		Heading:         "Login",
+		ExecutionStatus: pass,
+		ExecutionTime:   700,
+		Items: []item{
+			newTimedStep("Open page", "login", 100),
+			{Kind: conceptKind, Concept: &concept{Items: []item{newTimedStep("Enter user", "admin", 500)}}},
+		},
+	}
+	search := &scenario{
+		Heading:         "Search",
+		ExecutionStatus: fail,
+		ExecutionTime:   300,
+		Items:           []item{newTimedStep("Open page", "search", 300)},
+	}
+	res := &SuiteResult{SpecResults: []*spec{
+		{FileName: "login.spec", SpecHeading: "Login spec", ExecutionTime: 1200, ExecutionStatus: fail, Scenarios: []*scenario{search, login}},
+	}}
+
+	got := toPerformance(res)
+
+	checkEqual(t, "", "Login", got.Scenarios[0].Name)
+	checkEqual(t, "", "Search", got.Scenarios[1].Name)
+	checkEqual(t, "", 3, len(got.Steps))
+	checkEqual(t, "", `Enter user "admin"`, got.Steps[0].Name)
+	checkEqual(t, "", "Login spec", got.Steps[0].Spec)
+	checkEqual(t, "", 2, len(got.StepImplementations))
+	checkEqual(t, "", "Enter user {}", got.StepImplementations[0].Name)
+	checkEqual(t, "", "Open page {}", got.StepImplementations[1].Name)
+	checkEqual(t, "", 2, got.StepImplementations[1].Count)
+	checkEqual(t, "", duration(400), got.StepImplementations[1].Duration)
+	checkEqual(t, "", duration(200), got.StepImplementations[1].Average)
+	checkEqual(t, "", 2, len(got.Hooks))
+	checkEqual(t, "", "Login spec", got.Hooks[0].Name)
+	checkEqual(t, "", duration(200), got.Hooks[0].Duration)
+	checkEqual(t, "", "Login", got.Hooks[1].Name)
+	checkEqual(t, "", duration(100), got.Hooks[1].Duration)
+	checkEqual(t, "", duration(300), got.TotalHookTime)
+}
+
+func TestSlowestKeepsOnlyTheTopTimings(t *testing.T) {
+	var timings []*timing
+	for i := 0; i < performanceTopCount+5; i++ {
+		timings = append(timings, &timing{Name: fmt.Sprintf("t%d", i), Duration: duration(i)})
+	}
+
+	got := slowest(timings)
+
+	checkEqual(t, "", performanceTopCount, len(got))
+	checkEqual(t, "", fmt.Sprintf("t%d", performanceTopCount+4), got[0].Name)
+}
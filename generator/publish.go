@@ -0,0 +1,138 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/html-report/env"
+	"github.com/getgauge/html-report/logger"
+)
+
+const (
+	stagingSuffix = "staging"
+	backupSuffix  = "old"
+)
+
+// stagingDir returns the sibling directory in which a report for reportDir is rendered before being published.
+// It lives next to reportDir so that publishing is a rename within the same filesystem.
+func stagingDir(reportDir string) string {
+	return siblingDir(reportDir, stagingSuffix)
+}
+
+func backupDir(reportDir string) string {
+	return siblingDir(reportDir, backupSuffix)
+}
+
+func siblingDir(reportDir, suffix string) string {
+	dir, base := filepath.Split(filepath.Clean(reportDir))
+	return filepath.Join(dir, "."+base+"."+suffix)
+}
+
+// SiblingReportName returns the name of the report directory a staging or backup directory of the given name
+// belongs to, and false for any other name.
+func SiblingReportName(name string) (string, bool) {
+	if !strings.HasPrefix(name, ".") {
+		return "", false
+	}
+	for _, suffix := range []string{stagingSuffix, backupSuffix} {
+		if report, ok := strings.CutSuffix(name[1:], "."+suffix); ok && report != "" {
+			return report, true
+		}
+	}
+	return "", false
+}
+
+// prepareStagingDir creates an empty staging directory for reportDir, discarding leftovers of an earlier aborted run.
+func prepareStagingDir(reportDir string) (string, error) {
+	if err := recoverBackup(reportDir); err != nil {
+		return "", err
+	}
+	s := stagingDir(reportDir)
+	if err := os.RemoveAll(s); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(s, common.NewDirectoryPermissions); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// recoverBackup restores the previous report of reportDir if a run stopped while publishing, after moving it aside
+// and before moving the new one in. reportDir may have been created empty since.
+func recoverBackup(reportDir string) error {
+	old := backupDir(reportDir)
+	if _, err := os.Stat(old); err != nil {
+		return nil
+	}
+	if entries, err := os.ReadDir(reportDir); err == nil && len(entries) > 0 {
+		return os.RemoveAll(old)
+	}
+	if err := os.RemoveAll(reportDir); err != nil {
+		return err
+	}
+	logger.Debugf("Restoring the report %s left aside by an earlier run", reportDir)
+	return os.Rename(old, reportDir)
+}
+
+// publishReport swaps a fully rendered staging directory into reportDir. The previous report is moved aside and
+// the new one moved in its place, so readers see either of them in full. reportDir is only missing in between the
+// two renames, and should the run stop there, the previous report is restored by the next one.
+// Time-stamped reports of runs with overwrite_reports set to false, and the link to the latest of them, are carried
+// over to the new report. Everything else in reportDir belongs to the previous report and is replaced.
+func publishReport(staging, reportDir string) error {
+	if _, err := os.Stat(reportDir); os.IsNotExist(err) {
+		return os.Rename(staging, reportDir)
+	}
+	if err := carryOverEntries(reportDir, staging); err != nil {
+		return err
+	}
+	old := backupDir(reportDir)
+	if err := os.RemoveAll(old); err != nil {
+		return err
+	}
+	if err := os.Rename(reportDir, old); err != nil {
+		return err
+	}
+	if err := os.Rename(staging, reportDir); err != nil {
+		_ = os.Rename(old, reportDir)
+		return err
+	}
+	return os.RemoveAll(old)
+}
+
+// isCarriedOver tells whether an entry of a report directory was written there by other runs than the report's.
+func isCarriedOver(name string) bool {
+	if name == env.LatestReportLink || name == env.LatestReportRedirect {
+		return true
+	}
+	_, err := time.ParseInLocation(env.TimeStampedReportFormat, name, time.Local)
+	return err == nil
+}
+
+func carryOverEntries(from, to string) error {
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if !isCarriedOver(e.Name()) {
+			continue
+		}
+		dest := filepath.Join(to, e.Name())
+		if _, err := os.Lstat(dest); err == nil {
+			continue
+		}
+		if err := os.Rename(filepath.Join(from, e.Name()), dest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	helper "github.com/getgauge/html-report/test_helper"
+)
+
+func writeFileOrFail(t *testing.T, p, content string) {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStagingDirIsHiddenSibling(t *testing.T) {
+	want := filepath.Join("reports", ".html-report.staging")
+
+	got := stagingDir(filepath.Join("reports", "html-report"))
+
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestPublishReportToNewDirectory(t *testing.T) {
+	root := t.TempDir()
+	reportDir := filepath.Join(root, "html-report")
+	staging, err := prepareStagingDir(reportDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFileOrFail(t, filepath.Join(staging, "index.html"), "new")
+
+	if err := publishReport(staging, reportDir); err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+
+	b, _ := os.ReadFile(filepath.Join(reportDir, "index.html"))
+	if string(b) != "new" {
+		t.Errorf("Expected published index.html, got %q", string(b))
+	}
+	if helper.FileExists(staging) {
+		t.Errorf("Expected staging directory %s to be removed", staging)
+	}
+}
+
+func TestPublishReportReplacesExistingReport(t *testing.T) {
+	root := t.TempDir()
+	reportDir := filepath.Join(root, "html-report")
+	writeFileOrFail(t, filepath.Join(reportDir, "index.html"), "old")
+	writeFileOrFail(t, filepath.Join(reportDir, "2020-01-01_10.00.00", "index.html"), "earlier run")
+	writeFileOrFail(t, filepath.Join(reportDir, "latest.html"), "redirect")
+	writeFileOrFail(t, filepath.Join(reportDir, "timeline.html"), "stale")
+	staging, err := prepareStagingDir(reportDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFileOrFail(t, filepath.Join(staging, "index.html"), "new")
+
+	if err := publishReport(staging, reportDir); err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+
+	b, _ := os.ReadFile(filepath.Join(reportDir, "index.html"))
+	if string(b) != "new" {
+		t.Errorf("Expected published index.html, got %q", string(b))
+	}
+	if !helper.FileExists(filepath.Join(reportDir, "2020-01-01_10.00.00", "index.html")) {
+		t.Errorf("Expected time-stamped reports to be carried over")
+	}
+	if !helper.FileExists(filepath.Join(reportDir, "latest.html")) {
+		t.Errorf("Expected the link to the latest report to be carried over")
+	}
+	if helper.FileExists(filepath.Join(reportDir, "timeline.html")) {
+		t.Errorf("Expected pages of the previous report to be replaced")
+	}
+	if helper.FileExists(backupDir(reportDir)) {
+		t.Errorf("Expected backup directory to be removed")
+	}
+}
+
+func TestPrepareStagingDirRestoresReportLeftAsideByAnEarlierRun(t *testing.T) {
+	root := t.TempDir()
+	reportDir := filepath.Join(root, "html-report")
+	writeFileOrFail(t, filepath.Join(backupDir(reportDir), "index.html"), "old")
+	if err := os.MkdirAll(reportDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := prepareStagingDir(reportDir); err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+
+	b, _ := os.ReadFile(filepath.Join(reportDir, "index.html"))
+	if string(b) != "old" {
+		t.Errorf("Expected the previous report to be restored, got %q", string(b))
+	}
+	if helper.FileExists(backupDir(reportDir)) {
+		t.Errorf("Expected backup directory to be removed")
+	}
+}
+
+func TestSiblingReportName(t *testing.T) {
+	reportDir := filepath.Join("reports", "2016-06-03_12.29.00")
+	for _, d := range []string{stagingDir(reportDir), backupDir(reportDir)} {
+		got, ok := SiblingReportName(filepath.Base(d))
+		checkEqual(t, d, true, ok)
+		checkEqual(t, d, "2016-06-03_12.29.00", got)
+	}
+	for _, name := range []string{"2016-06-03_12.29.00", ".staging", "custom.old", ".latest.tmp"} {
+		if _, ok := SiblingReportName(name); ok {
+			t.Errorf("Expected %s not to be a staging or backup directory", name)
+		}
+	}
+}
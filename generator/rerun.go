@@ -0,0 +1,141 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"fmt"
+)
+
+// MergeRerun overlays the result of a rerun (e.g. gauge run --failed) onto the result of the original run.
+// Scenarios present in the rerun replace their counterparts in the original, specs which were not rerun are kept as is,
+// and all totals are recomputed so that the report reflects the final state of the suite.
+func MergeRerun(original, rerun *SuiteResult) *SuiteResult {
+	merged := *original
+	merged.Timestamp = rerun.Timestamp
+	merged.TimestampISO = rerun.TimestampISO
+	merged.ExecutionTime = original.ExecutionTime + rerun.ExecutionTime
+	merged.BeforeSuiteHookFailure = rerun.BeforeSuiteHookFailure
+	merged.AfterSuiteHookFailure = rerun.AfterSuiteHookFailure
+	merged.SpecResults = make([]*spec, 0, len(original.SpecResults))
+
+	rerunSpecs := make(map[string]*spec)
+	for _, s := range rerun.SpecResults {
+		rerunSpecs[s.FileName] = s
+	}
+	for _, s := range original.SpecResults {
+		if r, ok := rerunSpecs[s.FileName]; ok {
+			merged.SpecResults = append(merged.SpecResults, mergeSpec(s, r))
+			delete(rerunSpecs, s.FileName)
+		} else {
+			merged.SpecResults = append(merged.SpecResults, s)
+		}
+	}
+	for _, s := range rerun.SpecResults {
+		if _, ok := rerunSpecs[s.FileName]; ok {
+			merged.SpecResults = append(merged.SpecResults, s)
+		}
+	}
+	computeSuiteStatistics(&merged)
+	return &merged
+}
+
+func scenarioKey(s *scenario) string {
+	return fmt.Sprintf("%s|%d|%d", s.Heading, s.TableRowIndex, s.ScenarioTableRowIndex)
+}
+
+func mergeSpec(original, rerun *spec) *spec {
+	if len(rerun.Errors) > 0 {
+		return rerun
+	}
+	merged := *rerun
+	merged.ExecutionTime = original.ExecutionTime + rerun.ExecutionTime
+	merged.Scenarios = make([]*scenario, 0, len(original.Scenarios))
+	if merged.Datatable == nil {
+		merged.Datatable = original.Datatable
+	}
+
+	rerunScenarios := make(map[string]*scenario)
+	for _, s := range rerun.Scenarios {
+		rerunScenarios[scenarioKey(s)] = s
+	}
+	for _, s := range original.Scenarios {
+		r, ok := rerunScenarios[scenarioKey(s)]
+		if !ok {
+			merged.Scenarios = append(merged.Scenarios, s)
+			continue
+		}
+		r.IsRerun = true
+		r.PreviousExecutionStatus = s.ExecutionStatus
+		r.RetriesCount += s.RetriesCount
+		r.ExecutionIndex = s.ExecutionIndex
+		merged.Scenarios = append(merged.Scenarios, r)
+		delete(rerunScenarios, scenarioKey(s))
+	}
+	for _, s := range rerun.Scenarios {
+		if _, ok := rerunScenarios[scenarioKey(s)]; ok {
+			s.ExecutionIndex = len(original.Scenarios) + s.ExecutionIndex
+			merged.Scenarios = append(merged.Scenarios, s)
+		}
+	}
+
+	restoreScenarioTables(&merged)
+	if merged.IsTableDriven {
+		computeTableDrivenStatuses(&merged)
+	}
+	computeScenarioTableStatuses(&merged)
+	merged.PassedScenarioCount, merged.FailedScenarioCount, merged.SkippedScenarioCount = computeScenarioStatistics(&merged)
+	merged.ExecutionStatus = getMergedSpecStatus(&merged)
+	return &merged
+}
+
+// restoreScenarioTables makes sure that every group of scenario-table-driven scenarios carries its data table,
+// since only one scenario of each group holds it and that scenario may have come from either run.
+func restoreScenarioTables(s *spec) {
+	tables := make(map[string]*table)
+	for _, scn := range s.Scenarios {
+		if scn.IsScenarioTableDriven && scn.ScenarioDataTable != nil {
+			tables[scn.Heading] = scn.ScenarioDataTable
+		}
+	}
+	for _, scn := range s.Scenarios {
+		if scn.IsScenarioTableDriven {
+			scn.ScenarioDataTable = tables[scn.Heading]
+		}
+	}
+}
+
+func getMergedSpecStatus(s *spec) status {
+	if s.FailedScenarioCount > 0 || len(s.BeforeSpecHookFailures) > 0 || len(s.AfterSpecHookFailures) > 0 {
+		return fail
+	}
+	if len(s.Scenarios) > 0 && s.SkippedScenarioCount == len(s.Scenarios) {
+		return skip
+	}
+	return pass
+}
+
+func computeSuiteStatistics(res *SuiteResult) {
+	res.PassedSpecsCount, res.FailedSpecsCount, res.SkippedSpecsCount = 0, 0, 0
+	res.PassedScenarioCount, res.FailedScenarioCount, res.SkippedScenarioCount = 0, 0, 0
+	res.ExecutionStatus = pass
+	for _, s := range res.SpecResults {
+		switch s.ExecutionStatus {
+		case fail:
+			res.FailedSpecsCount++
+		case skip:
+			res.SkippedSpecsCount++
+		default:
+			res.PassedSpecsCount++
+		}
+		res.PassedScenarioCount += s.PassedScenarioCount
+		res.FailedScenarioCount += s.FailedScenarioCount
+		res.SkippedScenarioCount += s.SkippedScenarioCount
+	}
+	if res.FailedSpecsCount > 0 || res.BeforeSuiteHookFailure != nil || res.AfterSuiteHookFailure != nil {
+		res.ExecutionStatus = fail
+	}
+	res.SuccessRate = getSuccessRate(len(res.SpecResults), res.FailedSpecsCount+res.SkippedSpecsCount)
+}
@@ -0,0 +1,75 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"testing"
+)
+
+func newRerunSpec(fileName string, scenarios ...*scenario) *spec {
+	s := &spec{FileName: fileName, SpecHeading: fileName, ExecutionTime: 100, Scenarios: scenarios}
+	s.PassedScenarioCount, s.FailedScenarioCount, s.SkippedScenarioCount = computeScenarioStatistics(s)
+	s.ExecutionStatus = getMergedSpecStatus(s)
+	return s
+}
+
+func newRerunScenario(heading string, s status) *scenario {
+	return &scenario{Heading: heading, ExecutionStatus: s, TableRowIndex: -1, ScenarioTableRowIndex: -1}
+}
+
+func TestMergeRerunReplacesRerunScenarios(t *testing.T) {
+	original := &SuiteResult{
+		ExecutionTime: 1000,
+		SpecResults: []*spec{
+			newRerunSpec("a.spec", newRerunScenario("first", pass), newRerunScenario("second", fail)),
+			newRerunSpec("b.spec", newRerunScenario("third", pass)),
+		},
+	}
+	computeSuiteStatistics(original)
+	rerun := &SuiteResult{
+		ExecutionTime: 200,
+		Timestamp:     "rerun timestamp",
+		SpecResults:   []*spec{newRerunSpec("a.spec", newRerunScenario("second", pass))},
+	}
+
+	got := MergeRerun(original, rerun)
+
+	checkEqual(t, "", 2, len(got.SpecResults))
+	a := got.SpecResults[0]
+	checkEqual(t, "", pass, a.ExecutionStatus)
+	checkEqual(t, "", 2, a.PassedScenarioCount)
+	checkEqual(t, "", 0, a.FailedScenarioCount)
+	checkEqual(t, "", 2, len(a.Scenarios))
+	for _, scn := range a.Scenarios {
+		if scn.Heading == "second" {
+			checkEqual(t, "", true, scn.IsRerun)
+			checkEqual(t, "", fail, scn.PreviousExecutionStatus)
+		} else {
+			checkEqual(t, "", false, scn.IsRerun)
+		}
+	}
+	checkEqual(t, "", duration(200), a.ExecutionTime)
+	checkEqual(t, "", 0, got.FailedSpecsCount)
+	checkEqual(t, "", 2, got.PassedSpecsCount)
+	checkEqual(t, "", 3, got.PassedScenarioCount)
+	checkEqual(t, "", float32(100), got.SuccessRate)
+	checkEqual(t, "", pass, got.ExecutionStatus)
+	checkEqual(t, "", duration(1200), got.ExecutionTime)
+	checkEqual(t, "", "rerun timestamp", got.Timestamp)
+}
+
+func TestMergeRerunKeepsFailuresOfRerun(t *testing.T) {
+	original := &SuiteResult{SpecResults: []*spec{newRerunSpec("a.spec", newRerunScenario("first", fail))}}
+	computeSuiteStatistics(original)
+	rerun := &SuiteResult{SpecResults: []*spec{newRerunSpec("a.spec", newRerunScenario("first", fail))}}
+
+	got := MergeRerun(original, rerun)
+
+	checkEqual(t, "", fail, got.SpecResults[0].ExecutionStatus)
+	checkEqual(t, "", 1, got.FailedSpecsCount)
+	checkEqual(t, "", fail, got.ExecutionStatus)
+	checkEqual(t, "", float32(0), got.SuccessRate)
+}
@@ -0,0 +1,119 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/getgauge/html-report/env"
+)
+
+const (
+	scenariosPage     = "scenarios.html"
+	scenariosTemplate = "scenariosPage"
+	scenariosDataFile = "scenarios.js"
+)
+
+// scenarioEntry is a scenario in the data file of the scenarios page, which lists and filters them in the browser.
+type scenarioEntry struct {
+	Name       string `json:"Name"`
+	Spec       string `json:"Spec"`
+	ReportFile string `json:"ReportFile"`
+	// Status is known for the failed scenarios listed as known issues
+	Status   string   `json:"Status"`
+	Duration int64    `json:"Duration"`
+	Time     string   `json:"Time"`
+	Tags     []string `json:"Tags"`
+	Owners   []string `json:"Owners,omitempty"`
+}
+
+// toScenarioEntries flattens the scenarios of a suite, in the order of their specs. The tags of a scenario include
+// those of its spec.
+func toScenarioEntries(res *SuiteResult) []*scenarioEntry {
+	entries := make([]*scenarioEntry, 0)
+	for _, s := range res.SpecResults {
+		reportFile := toHTMLFileName(s.FileName, projectRoot)
+		for _, scn := range s.Scenarios {
+			st := string(scn.ExecutionStatus)
+			if scn.KnownIssue != nil {
+				st = "known"
+			}
+			tags := make([]string, 0, len(s.Tags)+len(scn.Tags))
+			for _, t := range append(append([]string{}, s.Tags...), scn.Tags...) {
+				if !slices.Contains(tags, t) {
+					tags = append(tags, t)
+				}
+			}
+			entries = append(entries, &scenarioEntry{
+				Name:       scn.Heading,
+				Spec:       s.SpecHeading,
+				ReportFile: reportFile,
+				Status:     st,
+				Duration:   scn.ExecutionTime.Milliseconds(),
+				Time:       scn.ExecutionTime.String(),
+				Tags:       tags,
+				Owners:     scn.Owners,
+			})
+		}
+	}
+	return entries
+}
+
+func hasScenarios(res *SuiteResult) bool {
+	for _, s := range res.SpecResults {
+		if len(s.Scenarios) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func writeScenariosData(res *SuiteResult, dir string) error {
+	env.CreateDirectory(filepath.Join(dir, "js"))
+	f, err := os.Create(filepath.Join(dir, "js", scenariosDataFile))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			return
+		}
+	}()
+	s, err := json.Marshal(toScenarioEntries(res))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "var scenarios = %s;", s)
+	return err
+}
+
+// generateScenariosPage renders the scenarios page along with its data file, so that the page stays small however
+// many scenarios the suite has.
+func generateScenariosPage(res *SuiteResult, reportsDir string) error {
+	if !res.hasScenariosPage {
+		return nil
+	}
+	if err := writeScenariosData(res, reportsDir); err != nil {
+		return err
+	}
+	p := filepath.Join(reportsDir, scenariosPage)
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer func(f *os.File) {
+		if err := f.Close(); err != nil {
+			return
+		}
+	}(f)
+	execTemplate(scenariosTemplate, f, res)
+	addHTMLFile(p)
+	return nil
+}
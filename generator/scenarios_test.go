@@ -0,0 +1,86 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	helper "github.com/getgauge/html-report/test_helper"
+)
+
+func TestToScenarioEntriesFlattensScenariosOfAllSpecs(t *testing.T) {
+	oldProjectRoot := projectRoot
+	projectRoot = "/project"
+	defer func() { projectRoot = oldProjectRoot }()
+	res := knownIssuesSuite()
+	res.SpecResults[0].Tags = []string{"checkout"}
+	res.SpecResults[0].Scenarios[0].Tags = []string{"smoke", "checkout"}
+	res.SpecResults[0].Scenarios[0].ExecutionTime = 1500
+	markKnownIssues(res, []*knownIssueRule{{scenario: "Login"}}, "/project")
+
+	got := toScenarioEntries(res)
+
+	checkEqual(t, "", 5, len(got))
+	checkEqual(t, "", &scenarioEntry{
+		Name:       "Pay by voucher",
+		Spec:       "Checkout",
+		ReportFile: "specs/checkout.html",
+		Status:     "fail",
+		Duration:   1500,
+		Time:       "1.5s",
+		Tags:       []string{"checkout", "smoke"},
+	}, got[0])
+	checkEqual(t, "", "known", got[3].Status)
+	checkEqual(t, "", "pass", got[4].Status)
+}
+
+func TestScenariosPageIsGeneratedWithItsData(t *testing.T) {
+	readTemplates(templateBasePath)
+	dir := t.TempDir()
+	res := knownIssuesSuite()
+	res.hasScenariosPage = true
+
+	if err := generateScenariosPage(res, dir); err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "js", scenariosDataFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); !strings.HasPrefix(got, `var scenarios = [{"Name":"Pay by voucher",`) {
+		t.Errorf("Expected the scenarios in the data file, got\n%s", got)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, scenariosPage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<tbody id="scenario-rows"></tbody>`,
+		`<span id="scenario-count" aria-live="polite" data-format="{shown} of {total} scenarios"></span>`,
+		`<script src="js/scenarios.js" type="text/javascript"></script>`,
+	} {
+		if got := helper.RemoveNewline(string(page)); !strings.Contains(got, want) {
+			t.Errorf("Expected %s in\n%s", want, got)
+		}
+	}
+}
+
+func TestReportNavLinksToScenariosPage(t *testing.T) {
+	readTemplates(templateBasePath)
+	buf := new(bytes.Buffer)
+
+	execTemplate("reportNav", buf, &overview{BasePath: "..", HasScenarios: true})
+
+	want := `<a href="../scenarios.html"><i class="fa fa-list" aria-hidden="true"></i> Scenarios</a>`
+	if got := helper.RemoveNewline(buf.String()); !strings.Contains(got, want) {
+		t.Errorf("Expected %s in\n%s", want, got)
+	}
+}
@@ -20,6 +20,7 @@ func NewSearchIndex() *SearchIndex {
 	var i SearchIndex
 	i.Tags = make(map[string][]string)
 	i.Specs = make(map[string][]string)
+	i.Owners = make(map[string][]string)
 	return &i
 }
 
@@ -32,6 +33,23 @@ func (i *SearchIndex) hasValueForTag(tag string, spec string) bool {
 	return false
 }
 
+func (i *SearchIndex) hasValueForOwner(owner string, spec string) bool {
+	for _, s := range i.Owners[owner] {
+		if s == spec {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *SearchIndex) addOwners(owners []string, spec string) {
+	for _, o := range owners {
+		if !i.hasValueForOwner(o, spec) {
+			i.Owners[o] = append(i.Owners[o], spec)
+		}
+	}
+}
+
 func (i *SearchIndex) hasSpec(specHeading string, specFileName string) bool {
 	for _, s := range i.Specs[specHeading] {
 		if s == specFileName {
@@ -72,12 +90,14 @@ func (i *SearchIndex) add(r *spec) {
 			i.Tags[t] = append(i.Tags[t], specFileName)
 		}
 	}
+	i.addOwners(r.Owners, specFileName)
 	for _, s := range r.Scenarios {
 		for _, t := range s.Tags {
 			if !i.hasValueForTag(t, specFileName) {
 				i.Tags[t] = append(i.Tags[t], specFileName)
 			}
 		}
+		i.addOwners(s.Owners, specFileName)
 	}
 	specHeading := r.SpecHeading
 	if !i.hasSpec(specHeading, specFileName) {
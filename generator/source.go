@@ -0,0 +1,155 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+)
+
+// source is the location of a spec, scenario, step or error in the project, used to link back to it.
+// Line is 0 if unknown.
+type source struct {
+	Path    string `json:"Path"`
+	AbsPath string `json:"AbsPath"`
+	Line    int    `json:"Line"`
+}
+
+// sourceLinkTemplate is the URL the source of a spec, scenario or step is linked to, with {path} replaced
+// by its path relative to the project, {abspath} by its absolute path and {line} by its line.
+var sourceLinkTemplate string
+
+func setSourceLink(template string) {
+	sourceLinkTemplate = template
+}
+
+func sourceLink(s *source) string {
+	if sourceLinkTemplate == "" || s == nil || s.Path == "" {
+		return ""
+	}
+	line := s.Line
+	if line < 1 {
+		line = 1
+	}
+	// Absolute paths start with a slash on Windows too, e.g. /C:/project/specs/example.spec.
+	abs := escapePath(s.AbsPath)
+	if !strings.HasPrefix(abs, "/") {
+		abs = "/" + abs
+	}
+	return strings.NewReplacer(
+		"{path}", escapePath(s.Path),
+		"{abspath}", abs,
+		"{line}", strconv.Itoa(line),
+	).Replace(sourceLinkTemplate)
+}
+
+func escapePath(p string) string {
+	segments := strings.Split(strings.ReplaceAll(p, `\`, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func newSource(projectRoot, file string, line int) *source {
+	if file == "" {
+		return nil
+	}
+	abs := file
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(projectRoot, file)
+	}
+	rel, err := filepath.Rel(projectRoot, abs)
+	if err != nil || projectRoot == "" {
+		rel = file
+	}
+	return &source{Path: filepath.ToSlash(rel), AbsPath: abs, Line: line}
+}
+
+// readSourceLines reads the spec file to locate its steps, which Gauge reports without line numbers.
+// It returns nil if the file is not available, e.g. when regenerating a report on another machine.
+func readSourceLines(file string) []string {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n")
+}
+
+// specHeadingLine returns the line of the spec heading, written as `# Heading` or underlined with `=`.
+func specHeadingLine(lines []string) int {
+	for i, l := range lines {
+		t := strings.TrimSpace(l)
+		if strings.HasPrefix(t, "#") && !strings.HasPrefix(t, "##") {
+			return i + 1
+		}
+		if t != "" && i+1 < len(lines) && strings.Trim(strings.TrimSpace(lines[i+1]), "=") == "" && strings.TrimSpace(lines[i+1]) != "" {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// stepLines returns the lines of the steps, starting with `*`, from line `from` to line `to` of the spec.
+func stepLines(lines []string, from, to int) []int {
+	var steps []int
+	for i := max(from, 1); i <= to && i <= len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i-1]), "*") {
+			steps = append(steps, i)
+		}
+	}
+	return steps
+}
+
+// setStepSources sets the source of the steps and concepts among the items to the given lines, in order.
+// Nothing is set if their number differs, as the lines could not be matched reliably.
+func setStepSources(items []item, lines []int, file *source) {
+	var steps []*step
+	for _, i := range items {
+		switch i.Kind {
+		case stepKind:
+			steps = append(steps, i.Step)
+		case conceptKind:
+			steps = append(steps, i.Concept.ConceptStep)
+		}
+	}
+	if file == nil || len(steps) == 0 || len(steps) != len(lines) {
+		return
+	}
+	for i, s := range steps {
+		s.Source = &source{Path: file.Path, AbsPath: file.AbsPath, Line: lines[i]}
+	}
+}
+
+// setScenarioSources sets the source of the scenario and, if the spec file could be read, of its steps.
+// Contexts are the steps between the spec heading and the first scenario, teardowns the ones after the `___` line.
+func setScenarioSources(scn *scenario, span *gm.Span, lines []string, file *source, firstScenarioLine int) {
+	start := int(span.GetStart())
+	if file == nil || start == 0 {
+		return
+	}
+	scn.Source = &source{Path: file.Path, AbsPath: file.AbsPath, Line: start}
+	if lines == nil {
+		return
+	}
+	end := int(span.GetEnd())
+	if end < start {
+		end = len(lines)
+	}
+	setStepSources(scn.Items, stepLines(lines, start, end), file)
+	setStepSources(scn.Contexts, stepLines(lines, 1, firstScenarioLine-1), file)
+	for i, l := range lines {
+		if t := strings.TrimSpace(l); len(t) >= 3 && strings.Trim(t, "_") == "" {
+			setStepSources(scn.Teardowns, stepLines(lines, i+2, len(lines)), file)
+			break
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+)
+
+const sourceSpec = `# Source links
+
+* Open the browser
+
+## First scenario
+
+* Say "hello"
+|Word|
+|----|
+|hi  |
+* Say "bye"
+
+## Second scenario
+* Login as "admin"
+
+___
+* Close the browser
+`
+
+func sourceSpecResult(t *testing.T, projectRoot string) *gm.ProtoSpecResult {
+	file := filepath.Join(projectRoot, "specs", "source links.spec")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(sourceSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stepItem := func(text string) *gm.ProtoItem {
+		return &gm.ProtoItem{ItemType: gm.ProtoItem_Step, Step: &gm.ProtoStep{ActualText: text, StepExecutionResult: &gm.ProtoStepExecutionResult{ExecutionResult: &gm.ProtoExecutionResult{}}}}
+	}
+	scenario := func(heading string, start, end int64, steps ...*gm.ProtoItem) *gm.ProtoItem {
+		return &gm.ProtoItem{ItemType: gm.ProtoItem_Scenario, Scenario: &gm.ProtoScenario{
+			ScenarioHeading: heading,
+			ExecutionStatus: gm.ExecutionStatus_PASSED,
+			Span:            &gm.Span{Start: start, End: end},
+			Contexts:        []*gm.ProtoItem{stepItem("Open the browser")},
+			ScenarioItems:   steps,
+			TearDownSteps:   []*gm.ProtoItem{stepItem("Close the browser")},
+		}}
+	}
+	return &gm.ProtoSpecResult{ProtoSpec: &gm.ProtoSpec{
+		SpecHeading: "Source links",
+		FileName:    file,
+		Items: []*gm.ProtoItem{
+			scenario("First scenario", 5, 11, stepItem(`Say "hello"`), stepItem(`Say "bye"`)),
+			scenario("Second scenario", 13, 14, stepItem(`Login as "admin"`)),
+		},
+	}}
+}
+
+func TestSourcesOfSpecScenariosAndSteps(t *testing.T) {
+	projectRoot := t.TempDir()
+
+	s := toSpec(sourceSpecResult(t, projectRoot), projectRoot)
+
+	checkEqual(t, "spec", &source{Path: "specs/source links.spec", AbsPath: filepath.Join(projectRoot, "specs", "source links.spec"), Line: 1}, s.Source)
+	lines := func(items []item) []int {
+		var l []int
+		for _, i := range items {
+			if i.Step.Source != nil {
+				l = append(l, i.Step.Source.Line)
+			}
+		}
+		return l
+	}
+	checkEqual(t, "first scenario", 5, s.Scenarios[0].Source.Line)
+	checkEqual(t, "first scenario steps", []int{7, 11}, lines(s.Scenarios[0].Items))
+	checkEqual(t, "second scenario", 13, s.Scenarios[1].Source.Line)
+	checkEqual(t, "second scenario steps", []int{14}, lines(s.Scenarios[1].Items))
+	checkEqual(t, "contexts", []int{3}, lines(s.Scenarios[0].Contexts))
+	checkEqual(t, "teardowns", []int{17}, lines(s.Scenarios[1].Teardowns))
+}
+
+func TestStepSourcesAreNotGuessed(t *testing.T) {
+	projectRoot := t.TempDir()
+	res := sourceSpecResult(t, projectRoot)
+	// A step missing from the result, e.g. of an older version of the spec, makes the lines ambiguous.
+	first := res.GetProtoSpec().GetItems()[0].GetScenario()
+	first.ScenarioItems = first.ScenarioItems[:1]
+
+	s := toSpec(res, projectRoot)
+
+	if src := s.Scenarios[0].Items[0].Step.Source; src != nil {
+		t.Errorf("Expected no source for the steps, got %v", src)
+	}
+	checkEqual(t, "", 5, s.Scenarios[0].Source.Line)
+}
+
+func TestSourceLink(t *testing.T) {
+	defer setSourceLink("")
+	s := &source{Path: "specs/source links.spec", AbsPath: "/home/dev/project/specs/source links.spec", Line: 7}
+
+	checkEqual(t, "unset", "", sourceLink(s))
+
+	setSourceLink("https://github.com/org/repo/blob/main/{path}#L{line}")
+	checkEqual(t, "repository", "https://github.com/org/repo/blob/main/specs/source%20links.spec#L7", sourceLink(s))
+	checkEqual(t, "no source", "", sourceLink(nil))
+
+	setSourceLink("vscode://file{abspath}:{line}")
+	checkEqual(t, "editor", "vscode://file/home/dev/project/specs/source%20links.spec:7", sourceLink(s))
+	checkEqual(t, "unknown line", "vscode://file/home/dev/project/specs/source%20links.spec:1", sourceLink(&source{Path: s.Path, AbsPath: s.AbsPath}))
+	checkEqual(t, "windows", "vscode://file/C:/project/example.spec:7", sourceLink(&source{Path: "example.spec", AbsPath: `C:\project\example.spec`, Line: 7}))
+}
+
+func TestSourceLinksAreRendered(t *testing.T) {
+	readTemplates(templateBasePath)
+	setSourceLink("https://example.com/{path}?line={line}&plain=1")
+	defer setSourceLink("")
+	projectRoot := t.TempDir()
+	s := toSpec(sourceSpecResult(t, projectRoot), projectRoot)
+	buf := new(bytes.Buffer)
+
+	execTemplate("specHeaderStartTag", buf, toSpecHeader(s))
+	execTemplate("scenarioHeaderStartDiv", buf, s.Scenarios[0])
+	execTemplate("stepStartDiv", buf, s.Scenarios[0].Items[1].Step)
+	execTemplate("specErrorDiv", buf, &spec{Errors: []buildError{{ErrorType: parseErrorType, Message: "Scenario heading expected", Source: &source{Path: "specs/broken.spec", Line: 3}}}})
+
+	for _, want := range []string{
+		`href="https://example.com/specs/source%20links.spec?line=1&amp;plain=1"`,
+		`href="https://example.com/specs/source%20links.spec?line=5&amp;plain=1"`,
+		`<a class="source-link step-source" href="https://example.com/specs/source%20links.spec?line=11&amp;plain=1"`,
+		`[Parse Error] Scenario heading expected <a class="source-link" href="https://example.com/specs/broken.spec?line=3&amp;plain=1"`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %s in\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	tagsPage     = "tags.html"
+	tagsTemplate = "tagsPage"
+)
+
+// tagStats is the health of the scenarios carrying a tag, either themselves or through their spec.
+type tagStats struct {
+	Name      string
+	Specs     int
+	Scenarios int
+	Passed    int
+	Failed    int
+	Skipped   int
+	Duration  duration
+	// PassRate is the percentage of executed, i.e. not skipped, scenarios which passed, -1 if none was executed
+	PassRate float64
+}
+
+// PassedWidth, FailedWidth and SkippedWidth are the shares of the scenarios in percent, for a bar chart.
+func (t *tagStats) PassedWidth() float64  { return share(t.Passed, t.Scenarios) }
+func (t *tagStats) FailedWidth() float64  { return share(t.Failed, t.Scenarios) }
+func (t *tagStats) SkippedWidth() float64 { return share(t.Skipped, t.Scenarios) }
+
+func share(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
+
+// toTagStats aggregates the scenarios of a suite by the tags of their specs and their own tags, sorted by tag.
+// A scenario tagged both ways is counted once. Rows of table driven scenarios are counted as scenarios, as in the overview.
+func toTagStats(res *SuiteResult) []*tagStats {
+	stats := make(map[string]*tagStats)
+	get := func(tag string) *tagStats {
+		if _, ok := stats[tag]; !ok {
+			stats[tag] = &tagStats{Name: tag}
+		}
+		return stats[tag]
+	}
+	for _, s := range res.SpecResults {
+		counted := make(map[string]bool)
+		count := func(tag string) {
+			if !counted[tag] {
+				counted[tag] = true
+				get(tag).Specs++
+			}
+		}
+		for _, tag := range s.Tags {
+			count(tag)
+		}
+		for _, scn := range s.Scenarios {
+			tags := make(map[string]bool, len(s.Tags)+len(scn.Tags))
+			for _, tag := range append(append([]string{}, s.Tags...), scn.Tags...) {
+				tags[tag] = true
+			}
+			for tag := range tags {
+				count(tag)
+				addScenario(get(tag), scn)
+			}
+		}
+	}
+	tags := make([]*tagStats, 0, len(stats))
+	for _, t := range stats {
+		t.PassRate = -1
+		if executed := t.Passed + t.Failed; executed > 0 {
+			t.PassRate = float64(t.Passed) * 100 / float64(executed)
+		}
+		tags = append(tags, t)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags
+}
+
+func addScenario(t *tagStats, scn *scenario) {
+	t.Scenarios++
+	t.Duration += scn.ExecutionTime
+	switch scn.ExecutionStatus {
+	case pass:
+		t.Passed++
+	case fail:
+		t.Failed++
+	case skip:
+		t.Skipped++
+	}
+}
+
+func hasTags(res *SuiteResult) bool {
+	for _, s := range res.SpecResults {
+		if len(s.Tags) > 0 {
+			return true
+		}
+		for _, scn := range s.Scenarios {
+			if len(scn.Tags) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func generateTagsPage(res *SuiteResult, reportsDir string) error {
+	if !res.hasTagsPage {
+		return nil
+	}
+	p := filepath.Join(reportsDir, tagsPage)
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer func(f *os.File) {
+		if err := f.Close(); err != nil {
+			return
+		}
+	}(f)
+	execTemplate(tagsTemplate, f, res)
+	addHTMLFile(p)
+	return nil
+}
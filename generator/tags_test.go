@@ -0,0 +1,96 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	helper "github.com/getgauge/html-report/test_helper"
+)
+
+func TestToTagStatsAggregatesScenariosBySpecAndScenarioTags(t *testing.T) {
+	res := &SuiteResult{SpecResults: []*spec{
+		{Tags: []string{"smoke"}, Scenarios: []*scenario{
+			{Tags: []string{"smoke", "login"}, ExecutionStatus: pass, ExecutionTime: 100},
+			{Tags: []string{"login"}, ExecutionStatus: fail, ExecutionTime: 200},
+		}},
+		{Scenarios: []*scenario{
+			{Tags: []string{"login"}, ExecutionStatus: skip},
+			{ExecutionStatus: pass},
+		}},
+	}}
+
+	got := toTagStats(res)
+
+	checkEqual(t, "", 2, len(got))
+	login, smoke := got[0], got[1]
+	checkEqual(t, "", "login", login.Name)
+	checkEqual(t, "", 2, login.Specs)
+	checkEqual(t, "", 3, login.Scenarios)
+	checkEqual(t, "", 1, login.Passed)
+	checkEqual(t, "", 1, login.Failed)
+	checkEqual(t, "", 1, login.Skipped)
+	checkEqual(t, "", 50.0, login.PassRate)
+	checkEqual(t, "", duration(300), login.Duration)
+	checkEqual(t, "", "smoke", smoke.Name)
+	checkEqual(t, "", 1, smoke.Specs)
+	checkEqual(t, "", 2, smoke.Scenarios)
+	checkEqual(t, "", 1, smoke.Passed)
+	checkEqual(t, "", 1, smoke.Failed)
+}
+
+func TestToTagStatsHasNoPassRateWithoutExecutedScenarios(t *testing.T) {
+	res := &SuiteResult{SpecResults: []*spec{
+		{Tags: []string{"wip"}, Scenarios: []*scenario{{ExecutionStatus: skip}, {ExecutionStatus: skip}}},
+	}}
+
+	got := toTagStats(res)
+
+	checkEqual(t, "", -1.0, got[0].PassRate)
+	checkEqual(t, "", 100.0, got[0].SkippedWidth())
+	checkEqual(t, "", 0.0, got[0].PassedWidth())
+}
+
+func TestTagsPageIsGeneratedOnlyWithTags(t *testing.T) {
+	reportDir := filepath.Join("_testdata", "e2e")
+	defer cleanUp(t, reportDir)
+	r := ToSuiteResult("", suiteRes3)
+	for _, s := range r.SpecResults {
+		s.Tags = nil
+		for _, scn := range s.Scenarios {
+			scn.Tags = nil
+		}
+	}
+
+	if err := GenerateReports(r, reportDir, templateBasePath, false); err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+	if helper.FileExists(filepath.Join(reportDir, tagsPage)) {
+		t.Errorf("Expected %s not to be generated without tags", tagsPage)
+	}
+
+	r.SpecResults[0].Tags = []string{"smoke & sanity"}
+	if err := GenerateReports(r, reportDir, templateBasePath, false); err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+	index, err := os.ReadFile(filepath.Join(reportDir, "index.html"))
+	if err != nil {
+		t.Fatalf("Error reading index.html: %s", err.Error())
+	}
+	if want := `href="tags.html"`; !strings.Contains(string(index), want) {
+		t.Errorf("Expected index.html to link to %s", tagsPage)
+	}
+	page, err := os.ReadFile(filepath.Join(reportDir, tagsPage))
+	if err != nil {
+		t.Fatalf("Error reading %s: %s", tagsPage, err.Error())
+	}
+	if want := `<a href="index.html#search=smoke+%26+sanity">smoke &amp; sanity</a>`; !strings.Contains(string(page), want) {
+		t.Errorf("Expected %s to link %s to the search of the index page", tagsPage, want)
+	}
+}
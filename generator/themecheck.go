@@ -0,0 +1,208 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/template"
+	"text/template/parse"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/html-report/theme"
+)
+
+// CheckTheme parses the templates of the theme at the given path and renders every page of the report
+// against a sample result, without running any spec. It returns the problems found, none if the theme works.
+func CheckTheme(themePath string) []error {
+	t, err := theme.Load(themePath, theme.PluginDefaultThemePath())
+	if err != nil {
+		return []error{err}
+	}
+	tmpl, err := parseTemplates(t)
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	if _, err := t.Translations(); err != nil {
+		errs = append(errs, err)
+	}
+	for _, m := range missingTemplates(tmpl) {
+		errs = append(errs, fmt.Errorf("template %s is used but not defined", m))
+	}
+	// A broken template shared by several pages fails each of them the same way, it is reported once.
+	seen := map[string]bool{}
+	render := func(name string, data interface{}) {
+		if tmpl.Lookup(name) == nil {
+			return
+		}
+		if err := tmpl.ExecuteTemplate(io.Discard, name, data); err != nil && !seen[err.Error()] {
+			seen[err.Error()] = true
+			errs = append(errs, err)
+		}
+	}
+	res := sampleSuiteResult()
+	res.hasTagsPage = hasTags(res)
+	res.hasScenariosPage = hasScenarios(res)
+	render("indexPage", res)
+	for _, r := range res.SpecResults {
+		propogateBasePath(r)
+		render("specPage", struct {
+			SuiteRes *SuiteResult
+			SpecRes  *spec
+		}{res, r})
+	}
+	render(performanceTemplate, res)
+	render(timelineTemplate, res)
+	render(tagsTemplate, res)
+	render(scenariosTemplate, res)
+	failed := *res
+	failed.BeforeSuiteHookFailure = toHookFailure(sampleHookFailure(), "Before Suite")
+	render("indexPageFailure", &failed)
+	return errs
+}
+
+// missingTemplates returns the names of the templates invoked with {{template}} that no file defines.
+// Rendering only reaches them for some results, so they are looked up in the parse trees instead.
+func missingTemplates(tmpl *template.Template) []string {
+	missing := map[string]bool{}
+	var walk func(n parse.Node)
+	walk = func(n parse.Node) {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Nodes {
+				walk(c)
+			}
+		case *parse.IfNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			if tmpl.Lookup(n.Name) == nil {
+				missing[n.Name] = true
+			}
+		}
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			walk(t.Tree.Root)
+		}
+	}
+	names := make([]string, 0, len(missing))
+	for m := range missing {
+		names = append(names, m)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sampleSuiteResult() *SuiteResult {
+	failedStep := sampleStep("Verify the number of vowels in <word> is <count>", true, false)
+	failedStep.Step.StepExecutionResult.ExecutionResult.ErrorMessage = "expected: 3 but was: 2"
+	failedStep.Step.StepExecutionResult.ExecutionResult.StackTrace = "StepImplementation.verifyVowelsCount(StepImplementation.java:26)"
+	failedStep.Step.Fragments = []*gm.Fragment{
+		{FragmentType: gm.Fragment_Text, Text: "Verify the number of vowels in "},
+		{FragmentType: gm.Fragment_Parameter, Parameter: &gm.Parameter{ParameterType: gm.Parameter_Static, Value: "gauge"}},
+		{FragmentType: gm.Fragment_Text, Text: " is "},
+		{FragmentType: gm.Fragment_Parameter, Parameter: &gm.Parameter{ParameterType: gm.Parameter_Dynamic, Value: "3"}},
+	}
+	psr := &gm.ProtoSuiteResult{
+		ProjectName:       "Sample Project",
+		Environment:       "default",
+		Tags:              "sample",
+		ExecutionTime:     3200,
+		SuccessRate:       50,
+		Timestamp:         "Jan 1, 2024 at 10:00am",
+		SpecsFailedCount:  1,
+		SpecsSkippedCount: 0,
+		PreHookMessages:   []string{"Before suite message"},
+		SpecResults: []*gm.ProtoSpecResult{
+			{
+				ExecutionTime: 1200,
+				ProtoSpec: &gm.ProtoSpec{
+					SpecHeading: "Passing specification",
+					FileName:    "specs/passing.spec",
+					Tags:        []string{"sample"},
+					Items: []*gm.ProtoItem{
+						{ItemType: gm.ProtoItem_Comment, Comment: &gm.ProtoComment{Text: "A specification with *markdown* comments."}},
+						{ItemType: gm.ProtoItem_Table, Table: &gm.ProtoTable{
+							Headers: &gm.ProtoTableRow{Cells: []string{"Word", "Count"}},
+							Rows:    []*gm.ProtoTableRow{{Cells: []string{"Gauge", "3"}}},
+						}},
+						sampleScenario("Passing scenario", gm.ExecutionStatus_PASSED, sampleStep("Say hello", false, false)),
+						sampleScenario("Skipped scenario", gm.ExecutionStatus_SKIPPED, sampleStep("Not executed", false, true)),
+					},
+				},
+			},
+			{
+				Failed:        true,
+				ExecutionTime: 2000,
+				ProtoSpec: &gm.ProtoSpec{
+					SpecHeading: "Failing specification",
+					FileName:    "specs/failing.spec",
+					Items: []*gm.ProtoItem{
+						sampleScenario("Failing scenario", gm.ExecutionStatus_FAILED, sampleStep("Say hello", false, false), failedStep),
+					},
+				},
+			},
+		},
+	}
+	res := ToSuiteResult("", psr)
+	res.Timeline = NewTimeline()
+	res.Metadata = map[string]string{"build number": "1024", "commit": "4f2a9c1", "target URL": "https://example.com"}
+	assignOwners(res, parseOwnerRules("specs/ team-core\nspecs/failing.spec team-checkout team-core"), "")
+	for i, s := range psr.GetSpecResults() {
+		info := &gm.ExecutionInfo{CurrentSpec: &gm.SpecInfo{Name: s.GetProtoSpec().GetSpecHeading(), FileName: s.GetProtoSpec().GetFileName(), IsFailed: s.GetFailed()}}
+		res.Timeline.SpecStarting(int32(i+1), info)
+		res.Timeline.SpecEnding(int32(i+1), info, s)
+	}
+	return res
+}
+
+func sampleScenario(heading string, status gm.ExecutionStatus, steps ...*gm.ProtoItem) *gm.ProtoItem {
+	return &gm.ProtoItem{
+		ItemType: gm.ProtoItem_Scenario,
+		Scenario: &gm.ProtoScenario{
+			ScenarioHeading: heading,
+			ExecutionStatus: status,
+			Failed:          status == gm.ExecutionStatus_FAILED,
+			Skipped:         status == gm.ExecutionStatus_SKIPPED,
+			ExecutionTime:   600,
+			ScenarioItems:   steps,
+		},
+	}
+}
+
+func sampleStep(text string, failed, skipped bool) *gm.ProtoItem {
+	return &gm.ProtoItem{
+		ItemType: gm.ProtoItem_Step,
+		Step: &gm.ProtoStep{
+			ActualText: text,
+			ParsedText: text,
+			Fragments:  []*gm.Fragment{{FragmentType: gm.Fragment_Text, Text: text}},
+			StepExecutionResult: &gm.ProtoStepExecutionResult{
+				ExecutionResult: &gm.ProtoExecutionResult{Failed: failed, ExecutionTime: 300},
+				Skipped:         skipped,
+			},
+		},
+	}
+}
+
+func sampleHookFailure() *gm.ProtoHookFailure {
+	return &gm.ProtoHookFailure{
+		ErrorMessage: "java.lang.RuntimeException: could not connect to the database",
+		StackTrace:   "Hooks.beforeSuite(Hooks.java:12)",
+	}
+}
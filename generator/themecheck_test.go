@@ -0,0 +1,55 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckThemeFindsNoProblemInDefaultTheme(t *testing.T) {
+	if errs := CheckTheme(templateBasePath); len(errs) != 0 {
+		t.Errorf("Expected no problems, got %v", errs)
+	}
+}
+
+func TestCheckThemeReportsMissingTemplatesAndRuntimeErrors(t *testing.T) {
+	dir := t.TempDir()
+	manifest := fmt.Sprintf(`{"name": "broken", "parent": %q}`, filepath.ToSlash(templateBasePath))
+	if err := os.WriteFile(filepath.Join(dir, "theme.json"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "views"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	tmpl := `{{define "bodyFooterTag"}}{{len 3}}{{end}}{{define "unused"}}{{template "companyLogo"}}{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "views", "broken.tmpl"), []byte(tmpl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := CheckTheme(dir)
+
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 problems, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "template companyLogo is used but not defined") {
+		t.Errorf("Expected the missing template to be reported, got %s", errs[0].Error())
+	}
+	if !strings.Contains(errs[1].Error(), "len of type int") {
+		t.Errorf("Expected the runtime error to be reported, got %s", errs[1].Error())
+	}
+}
+
+func TestCheckThemeReportsInvalidTheme(t *testing.T) {
+	errs := CheckTheme(filepath.Join(t.TempDir(), "missing"))
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "does not exist") {
+		t.Errorf("Expected a missing theme to be reported, got %v", errs)
+	}
+}
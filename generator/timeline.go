@@ -0,0 +1,231 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+)
+
+const (
+	timelinePage     = "timeline.html"
+	timelineTemplate = "timelinePage"
+)
+
+type timelineEntry struct {
+	Stream   int32
+	FileName string
+	Spec     string
+	Scenario string
+	Status   status
+	Start    time.Time
+	End      time.Time
+}
+
+// Timeline records when specs and scenarios started and ended on each execution stream.
+// Gauge executes one spec and one scenario at a time per stream, so an entry in progress is identified by its stream.
+type Timeline struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	specs     map[int32]*timelineEntry
+	scenarios map[int32]*timelineEntry
+	entries   []*timelineEntry
+}
+
+// NewTimeline creates an empty Timeline
+func NewTimeline() *Timeline {
+	return &Timeline{
+		now:       time.Now,
+		specs:     make(map[int32]*timelineEntry),
+		scenarios: make(map[int32]*timelineEntry),
+	}
+}
+
+// SpecStarting records the start of a spec on a stream
+func (t *Timeline) SpecStarting(stream int32, info *gm.ExecutionInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.specs[stream] = &timelineEntry{
+		Stream:   stream,
+		FileName: info.GetCurrentSpec().GetFileName(),
+		Spec:     info.GetCurrentSpec().GetName(),
+		Start:    t.now(),
+	}
+}
+
+// SpecEnding records the end of the spec running on a stream, with its result
+func (t *Timeline) SpecEnding(stream int32, info *gm.ExecutionInfo, res *gm.ProtoSpecResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.end(t.specs, stream, endingStatus(info.GetCurrentSpec().GetIsFailed(), res.GetSkipped()))
+}
+
+// ScenarioStarting records the start of a scenario on a stream
+func (t *Timeline) ScenarioStarting(stream int32, info *gm.ExecutionInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.scenarios[stream] = &timelineEntry{
+		Stream:   stream,
+		FileName: info.GetCurrentSpec().GetFileName(),
+		Spec:     info.GetCurrentSpec().GetName(),
+		Scenario: info.GetCurrentScenario().GetName(),
+		Start:    t.now(),
+	}
+}
+
+// ScenarioEnding records the end of the scenario running on a stream, with its result
+func (t *Timeline) ScenarioEnding(stream int32, info *gm.ExecutionInfo, res *gm.ProtoScenarioResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	scn := res.GetProtoItem().GetScenario()
+	if scn == nil {
+		scn = res.GetProtoItem().GetTableDrivenScenario().GetScenario()
+	}
+	skipped := scn.GetSkipped() || scn.GetExecutionStatus() == gm.ExecutionStatus_SKIPPED
+	t.end(t.scenarios, stream, endingStatus(info.GetCurrentScenario().GetIsFailed(), skipped))
+}
+
+func endingStatus(failed, skipped bool) status {
+	switch {
+	case failed:
+		return fail
+	case skipped:
+		return skip
+	default:
+		return pass
+	}
+}
+
+func (t *Timeline) end(running map[int32]*timelineEntry, stream int32, s status) {
+	e, ok := running[stream]
+	if !ok {
+		return
+	}
+	delete(running, stream)
+	e.End = t.now()
+	e.Status = s
+	t.entries = append(t.entries, e)
+}
+
+func (t *Timeline) isEmpty() bool {
+	if t == nil {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.entries) == 0
+}
+
+type timelineBar struct {
+	Name       string
+	Spec       string
+	ReportFile string
+	Status     status
+	Duration   duration
+	Left       string
+	Width      string
+}
+
+type timelineStream struct {
+	ID        int32
+	Specs     []*timelineBar
+	Scenarios []*timelineBar
+	Busy      duration
+	Idle      duration
+}
+
+type timeline struct {
+	Duration duration
+	Streams  []*timelineStream
+}
+
+// toTimeline lays out the recorded specs and scenarios of every stream relative to the start of the first one.
+// Positions are percentages of the total duration, so that the bars of all streams share the same scale.
+func toTimeline(res *SuiteResult) *timeline {
+	tl := &timeline{}
+	if res.Timeline.isEmpty() {
+		return tl
+	}
+	res.Timeline.mu.Lock()
+	entries := append([]*timelineEntry{}, res.Timeline.entries...)
+	res.Timeline.mu.Unlock()
+
+	start, end := entries[0].Start, entries[0].End
+	for _, e := range entries {
+		if e.Start.Before(start) {
+			start = e.Start
+		}
+		if e.End.After(end) {
+			end = e.End
+		}
+	}
+	total := end.Sub(start)
+	tl.Duration = duration(total.Milliseconds())
+	percent := func(d time.Duration) string {
+		if total <= 0 {
+			return "0"
+		}
+		return fmt.Sprintf("%.2f", 100*float64(d)/float64(total))
+	}
+
+	streams := make(map[int32]*timelineStream)
+	for _, e := range entries {
+		s, ok := streams[e.Stream]
+		if !ok {
+			s = &timelineStream{ID: e.Stream}
+			streams[e.Stream] = s
+			tl.Streams = append(tl.Streams, s)
+		}
+		bar := &timelineBar{
+			Name:       e.Spec,
+			Spec:       e.Spec,
+			ReportFile: toHTMLFileName(e.FileName, projectRoot),
+			Status:     e.Status,
+			Duration:   duration(e.End.Sub(e.Start).Milliseconds()),
+			Left:       percent(e.Start.Sub(start)),
+			Width:      percent(e.End.Sub(e.Start)),
+		}
+		if e.Scenario != "" {
+			bar.Name = e.Scenario
+			s.Scenarios = append(s.Scenarios, bar)
+			continue
+		}
+		s.Specs = append(s.Specs, bar)
+		s.Busy += bar.Duration
+	}
+	sort.Slice(tl.Streams, func(i, j int) bool { return tl.Streams[i].ID < tl.Streams[j].ID })
+	for _, s := range tl.Streams {
+		if s.Idle = tl.Duration - s.Busy; s.Idle < 0 {
+			s.Idle = 0
+		}
+	}
+	return tl
+}
+
+func generateTimelinePage(res *SuiteResult, reportsDir string) error {
+	if res.Timeline.isEmpty() || parsedTemplates.Lookup(timelineTemplate) == nil {
+		return nil
+	}
+	p := filepath.Join(reportsDir, timelinePage)
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer func(f *os.File) {
+		if err := f.Close(); err != nil {
+			return
+		}
+	}(f)
+	execTemplate(timelineTemplate, f, res)
+	addHTMLFile(p)
+	return nil
+}
@@ -0,0 +1,118 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	helper "github.com/getgauge/html-report/test_helper"
+)
+
+func newTestTimeline(start time.Time, offsets ...int) *Timeline {
+	t := NewTimeline()
+	i := 0
+	t.now = func() time.Time {
+		at := start.Add(time.Duration(offsets[i]) * time.Second)
+		i++
+		return at
+	}
+	return t
+}
+
+func executionInfo(fileName, scenario string, failed bool) *gm.ExecutionInfo {
+	return &gm.ExecutionInfo{
+		CurrentSpec:     &gm.SpecInfo{Name: fileName, FileName: fileName, IsFailed: failed},
+		CurrentScenario: &gm.ScenarioInfo{Name: scenario, IsFailed: failed},
+	}
+}
+
+func TestToTimelineLaysOutStreams(t *testing.T) {
+	tl := newTestTimeline(time.Now(), 0, 0, 2, 4, 1, 1, 3, 3)
+	tl.SpecStarting(1, executionInfo("a.spec", "", false))
+	tl.ScenarioStarting(1, executionInfo("a.spec", "first", false))
+	tl.ScenarioEnding(1, executionInfo("a.spec", "first", false), nil)
+	tl.SpecEnding(1, executionInfo("a.spec", "", false), nil)
+	tl.SpecStarting(2, executionInfo("b.spec", "", false))
+	tl.ScenarioStarting(2, executionInfo("b.spec", "second", true))
+	tl.ScenarioEnding(2, executionInfo("b.spec", "second", true), nil)
+	tl.SpecEnding(2, executionInfo("b.spec", "", true), nil)
+
+	got := toTimeline(&SuiteResult{Timeline: tl})
+
+	checkEqual(t, "", duration(4000), got.Duration)
+	checkEqual(t, "", 2, len(got.Streams))
+	first, second := got.Streams[0], got.Streams[1]
+	checkEqual(t, "", int32(1), first.ID)
+	checkEqual(t, "", "0.00", first.Specs[0].Left)
+	checkEqual(t, "", "100.00", first.Specs[0].Width)
+	checkEqual(t, "", "first", first.Scenarios[0].Name)
+	checkEqual(t, "", "50.00", first.Scenarios[0].Width)
+	checkEqual(t, "", duration(0), first.Idle)
+	checkEqual(t, "", "25.00", second.Specs[0].Left)
+	checkEqual(t, "", "50.00", second.Specs[0].Width)
+	checkEqual(t, "", fail, second.Specs[0].Status)
+	checkEqual(t, "", duration(2000), second.Busy)
+	checkEqual(t, "", duration(2000), second.Idle)
+}
+
+func TestToTimelineShowsSkippedSpecsAndScenarios(t *testing.T) {
+	tl := newTestTimeline(time.Now(), 0, 0, 1, 1)
+	tl.SpecStarting(1, executionInfo("a.spec", "", false))
+	tl.ScenarioStarting(1, executionInfo("a.spec", "first", false))
+	tl.ScenarioEnding(1, executionInfo("a.spec", "first", false), &gm.ProtoScenarioResult{
+		ProtoItem: &gm.ProtoItem{Scenario: &gm.ProtoScenario{ExecutionStatus: gm.ExecutionStatus_SKIPPED, Skipped: true}},
+	})
+	tl.SpecEnding(1, executionInfo("a.spec", "", false), &gm.ProtoSpecResult{Skipped: true})
+
+	got := toTimeline(&SuiteResult{Timeline: tl})
+
+	checkEqual(t, "", skip, got.Streams[0].Specs[0].Status)
+	checkEqual(t, "", skip, got.Streams[0].Scenarios[0].Status)
+}
+
+func TestTimelineIgnoresEndingWithoutStart(t *testing.T) {
+	tl := NewTimeline()
+	tl.SpecEnding(1, executionInfo("a.spec", "", false), nil)
+
+	if !tl.isEmpty() {
+		t.Errorf("Expected timeline to be empty")
+	}
+}
+
+func TestTimelinePageIsGeneratedOnlyWithTimeline(t *testing.T) {
+	reportDir := filepath.Join("_testdata", "e2e")
+	defer cleanUp(t, reportDir)
+	r := ToSuiteResult("", suiteRes3)
+
+	if err := GenerateReports(r, reportDir, templateBasePath, false); err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+	if helper.FileExists(filepath.Join(reportDir, timelinePage)) {
+		t.Errorf("Expected %s not to be generated without a timeline", timelinePage)
+	}
+
+	r.Timeline = newTestTimeline(time.Now(), 0, 1)
+	r.Timeline.SpecStarting(0, executionInfo("passing_specification_1.spec", "", false))
+	r.Timeline.SpecEnding(0, executionInfo("passing_specification_1.spec", "", false), nil)
+	if err := GenerateReports(r, reportDir, templateBasePath, false); err != nil {
+		t.Fatalf("Expected error to be nil. Got: %s", err.Error())
+	}
+	b, err := os.ReadFile(filepath.Join(reportDir, "index.html"))
+	if err != nil {
+		t.Fatalf("Error reading index.html: %s", err.Error())
+	}
+	if !helper.FileExists(filepath.Join(reportDir, timelinePage)) {
+		t.Errorf("Expected %s to be generated", timelinePage)
+	}
+	if want := `href="timeline.html"`; !strings.Contains(string(b), want) {
+		t.Errorf("Expected index.html to link to %s", timelinePage)
+	}
+}
@@ -0,0 +1,36 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"time"
+	// The time zone database is embedded since the machines the report is generated on, e.g. Windows
+	// agents or slim Docker images, don't always have one.
+	_ "time/tzdata"
+
+	"github.com/getgauge/html-report/logger"
+)
+
+// location is the time zone the timestamps of the report are shown in
+var location = time.Local
+
+// timezone is the name of the configured time zone, empty if the one of the machine is used
+var timezone string
+
+// setTimezone sets the IANA time zone, e.g. Europe/Berlin, the timestamps of the report are shown in.
+// The time zone of the machine is used if none is set.
+func setTimezone(name string) {
+	location, timezone = time.Local, ""
+	if name == "" {
+		return
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		logger.Warnf("Unknown timezone %s, using the local time zone", name)
+		return
+	}
+	location, timezone = loc, name
+}
@@ -0,0 +1,68 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimestampIsShownInConfiguredTimezone(t *testing.T) {
+	defer setTimezone("")
+	tests := []struct {
+		timezone string
+		want     string
+	}{
+		{"Asia/Tokyo", "Jun 3, 2016 at 9:29pm JST"},
+		{"UTC", "Jun 3, 2016 at 12:29pm UTC"},
+		{"America/New_York", "Jun 3, 2016 at 8:29am EDT"},
+	}
+	for _, test := range tests {
+		setTimezone(test.timezone)
+		checkEqual(t, test.timezone, test.want, toFormattedLocalTime("2016-06-03T12:29:00Z", ""))
+	}
+}
+
+func TestUnknownTimezoneFallsBackToLocal(t *testing.T) {
+	setTimezone("Mars/Olympus_Mons")
+	defer setTimezone("")
+
+	if location != time.Local {
+		t.Errorf("Expected the local time zone, got %s", location)
+	}
+}
+
+func TestGeneratedOnEmbedsISOTimestamp(t *testing.T) {
+	readTemplates(templateBasePath)
+	res := ToSuiteResult("", newProtoSuiteRes(false, 0, 0, 100, nil, nil, passSpecRes1))
+	res.TimestampISO = "2016-06-03T12:29:00Z"
+	setTimezone("UTC")
+	defer setTimezone("")
+	buf := new(bytes.Buffer)
+
+	execTemplate("reportOverviewTag", buf, toOverview(res, ""))
+
+	want := `<time class="generated-on" datetime="2016-06-03T12:29:00Z" data-timezone="UTC">Jun 3, 2016 at 12:29pm UTC</time>`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected %s in\n%s", want, buf.String())
+	}
+}
+
+func TestGeneratedOnHasNoTimezoneWithoutConfiguredOne(t *testing.T) {
+	readTemplates(templateBasePath)
+	res := ToSuiteResult("", newProtoSuiteRes(false, 0, 0, 100, nil, nil, passSpecRes1))
+	res.TimestampISO = "2016-06-03T12:29:00Z"
+	setTimezone("")
+	buf := new(bytes.Buffer)
+
+	execTemplate("reportOverviewTag", buf, toOverview(res, ""))
+
+	if strings.Contains(buf.String(), "data-timezone") {
+		t.Errorf("Expected no time zone in\n%s", buf.String())
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 	"time"
 
@@ -21,7 +20,6 @@ import (
 )
 
 const (
-	execTimeFormat      = "15:04:05"
 	generatedTimeFormat = "Jan 2, 2006 at 3:04pm"
 	dothtml             = ".html"
 )
@@ -35,7 +33,7 @@ func ToSuiteResult(pRoot string, psr *gm.ProtoSuiteResult) *SuiteResult {
 		ProjectName:            psr.GetProjectName(),
 		Environment:            psr.GetEnvironment(),
 		Tags:                   psr.GetTags(),
-		ExecutionTime:          psr.GetExecutionTime(),
+		ExecutionTime:          duration(psr.GetExecutionTime()),
 		PassedSpecsCount:       len(psr.GetSpecResults()) - int(psr.GetSpecsFailedCount()) - int(psr.GetSpecsSkippedCount()),
 		FailedSpecsCount:       int(psr.GetSpecsFailedCount()),
 		SkippedSpecsCount:      int(psr.GetSpecsSkippedCount()),
@@ -43,6 +41,7 @@ func ToSuiteResult(pRoot string, psr *gm.ProtoSuiteResult) *SuiteResult {
 		AfterSuiteHookFailure:  toHookFailure(psr.GetPostHookFailure(), "After Suite"),
 		SuccessRate:            psr.GetSuccessRate(),
 		Timestamp:              toFormattedLocalTime(psr.GetTimestampISO(), psr.GetTimestamp()), //nolint - deprecated, but read here for backward compatibility
+		TimestampISO:           psr.GetTimestampISO(),
 		ExecutionStatus:        pass,
 		PreHookMessages:        psr.GetPreHookMessages(),
 		PostHookMessages:       psr.GetPostHookMessages(),
@@ -85,13 +84,20 @@ func toFormattedLocalTime(isoTimestamp string, humanReadableTimestamp string) st
 		logger.Debugf("[Warning] Failed to parse timestamp %s due to %s, falling back to pre-humanized timestamp", isoTimestamp, err.Error())
 		return humanReadableTimestamp
 	}
-	return parsedTime.Local().Format(generatedTimeFormat)
+	t := parsedTime.In(location)
+	if location == time.Local {
+		return t.Format(translate(generatedTimeFormat))
+	}
+	// The zone is shown when it is configured, as it can differ from the one of the reader.
+	return t.Format(translate(generatedTimeFormat)) + " " + t.Format("MST")
 }
 
 func toNestedSuiteResult(basePath string, result *SuiteResult) *SuiteResult {
 	sr := &SuiteResult{
 		ProjectName:            result.ProjectName,
 		Timestamp:              result.Timestamp,
+		TimestampISO:           result.TimestampISO,
+		Metadata:               result.Metadata,
 		Environment:            result.Environment,
 		Tags:                   result.Tags,
 		BeforeSuiteHookFailure: result.BeforeSuiteHookFailure,
@@ -99,12 +105,20 @@ func toNestedSuiteResult(basePath string, result *SuiteResult) *SuiteResult {
 		ExecutionStatus:        pass,
 		SpecResults:            getNestedSpecResults(result.SpecResults, basePath),
 		BasePath:               filepath.Clean(basePath),
+		Timeline:               result.Timeline,
+		hasTagsPage:            result.hasTagsPage,
+		hasScenariosPage:       result.hasScenariosPage,
+		hasNestedSpecs:         result.hasNestedSpecs,
 	}
 
 	for _, spec := range sr.SpecResults {
 		if spec.ExecutionStatus == fail {
 			sr.ExecutionStatus = fail
-			sr.FailedSpecsCount++
+			if spec.HasOnlyKnownIssues {
+				sr.KnownIssueSpecsCount++
+			} else {
+				sr.FailedSpecsCount++
+			}
 		}
 		if spec.ExecutionStatus == skip {
 			sr.SkippedSpecsCount++
@@ -116,6 +130,7 @@ func toNestedSuiteResult(basePath string, result *SuiteResult) *SuiteResult {
 		sr.PassedScenarioCount += spec.PassedScenarioCount
 		sr.FailedScenarioCount += spec.FailedScenarioCount
 		sr.SkippedScenarioCount += spec.SkippedScenarioCount
+		sr.KnownIssueScenarioCount += spec.KnownIssueScenarioCount
 	}
 	sr.SuccessRate = getSuccessRate(len(sr.SpecResults), sr.FailedSpecsCount+sr.SkippedSpecsCount)
 	return sr
@@ -132,7 +147,8 @@ func getNestedSpecResults(specResults []*spec, basePath string) []*spec {
 	nestedSpecResults := make([]*spec, 0)
 	for _, specResult := range specResults {
 		rel, _ := filepath.Rel(projectRoot, specResult.FileName)
-		if strings.HasPrefix(rel, basePath) {
+		// The separator keeps the specs of a sibling folder sharing the prefix, e.g. specs/foobar for specs/foo, out
+		if strings.HasPrefix(rel, basePath+string(filepath.Separator)) {
 			nestedSpecResults = append(nestedSpecResults, specResult)
 		}
 	}
@@ -165,10 +181,12 @@ func toOverview(res *SuiteResult, filePath string) *overview {
 		Env:                     res.Environment,
 		Tags:                    res.Tags,
 		SuccessRate:             res.SuccessRate,
-		ExecutionTime:           formatTime(res.ExecutionTime),
-		Timestamp:               res.Timestamp,
-		Summary:                 &summary{Failed: res.FailedSpecsCount, Total: totalSpecs, Passed: res.PassedSpecsCount, Skipped: res.SkippedSpecsCount},
-		ScenarioSummary:         &summary{Failed: res.FailedScenarioCount, Total: totalScenarios, Passed: res.PassedScenarioCount, Skipped: res.SkippedScenarioCount},
+		ExecutionTime:           res.ExecutionTime,
+		Timestamp:               toFormattedLocalTime(res.TimestampISO, res.Timestamp),
+		TimestampISO:            res.TimestampISO,
+		Metadata:                toMetadataEntries(res.Metadata),
+		Summary:                 &summary{Failed: res.FailedSpecsCount, Total: totalSpecs, Passed: res.PassedSpecsCount, Skipped: res.SkippedSpecsCount, Known: res.KnownIssueSpecsCount},
+		ScenarioSummary:         &summary{Failed: res.FailedScenarioCount, Total: totalScenarios, Passed: res.PassedScenarioCount, Skipped: res.SkippedScenarioCount, Known: res.KnownIssueScenarioCount},
 		BasePath:                base,
 		PreHookMessages:         res.PreHookMessages,
 		PostHookMessages:        res.PostHookMessages,
@@ -176,6 +194,10 @@ func toOverview(res *SuiteResult, filePath string) *overview {
 		PostHookScreenshots:     res.PostHookScreenshots,
 		PreHookScreenshotFiles:  res.PreHookScreenshotFiles,
 		PostHookScreenshotFiles: res.PostHookScreenshotFiles,
+		HasTimeline:             !res.Timeline.isEmpty(),
+		HasTags:                 res.hasTagsPage,
+		HasScenarios:            res.hasScenariosPage,
+		ColorScheme:             colorScheme,
 	}
 }
 
@@ -220,22 +242,32 @@ func getFilePathBasedOnSpecLocation(specFilePath, path string) string {
 func toSidebar(res *SuiteResult, specFilePath string) *sidebar {
 	basePath := getFilePathBasedOnSpecLocation(specFilePath, res.BasePath)
 	specsMetaList := make([]*specsMeta, 0)
-	for _, specRes := range res.SpecResults {
+	sourceIndex := sourceIndexes(res.SpecResults)
+	for i, specRes := range res.SpecResults {
 		sm := &specsMeta{
-			SpecName:      specRes.SpecHeading,
-			ExecutionTime: formatTime(specRes.ExecutionTime),
-			Failed:        specRes.ExecutionStatus == fail,
-			Skipped:       specRes.ExecutionStatus == skip,
-			Tags:          specRes.Tags,
-			ReportFile:    toHTMLFileName(specRes.FileName, basePath),
+			SpecName:       specRes.SpecHeading,
+			ExecutionTime:  specRes.ExecutionTime,
+			Failed:         specRes.ExecutionStatus == fail && !specRes.HasOnlyKnownIssues,
+			Skipped:        specRes.ExecutionStatus == skip,
+			Known:          specRes.HasOnlyKnownIssues,
+			Tags:           specRes.Tags,
+			ReportFile:     toHTMLFileName(specRes.FileName, basePath),
+			ExecutionIndex: i,
+			SourceIndex:    sourceIndex[specRes],
 		}
 		specsMetaList = append(specsMetaList, sm)
 	}
-	sort.Sort(byStatus(specsMetaList))
+	var tree *specsDir
+	if res.hasNestedSpecs {
+		tree = toSpecsTree(res, specsMetaList, specFilePath, basePath, specsOrder)
+	}
+	sortSpecsMeta(specsMetaList, specsOrder)
 
 	return &sidebar{
 		IsBeforeHookFailure: res.BeforeSuiteHookFailure != nil,
 		Specs:               specsMetaList,
+		Order:               specsOrder,
+		Tree:                tree,
 	}
 }
 
@@ -254,6 +286,9 @@ func (s byStatus) Less(i, j int) bool {
 
 func getState(r *specsMeta) int {
 	if r.Failed {
+		return -2
+	}
+	if r.Known {
 		return -1
 	}
 	if r.Skipped {
@@ -324,10 +359,11 @@ func getSpecName(s *gm.ProtoSpec) string {
 func toSpecHeader(res *spec) *specHeader {
 	return &specHeader{
 		SpecName:      res.SpecHeading,
-		ExecutionTime: formatTime(res.ExecutionTime),
+		ExecutionTime: res.ExecutionTime,
 		FileName:      res.SpecFileName,
 		Tags:          res.Tags,
 		Summary:       toScenarioSummary(res),
+		Source:        res.Source,
 	}
 }
 
@@ -343,7 +379,7 @@ func toSpec(res *gm.ProtoSpecResult, projectRoot string) *spec {
 		SpecFileName:           res.GetProtoSpec().GetFileName(),
 		SpecHeading:            res.GetProtoSpec().GetSpecHeading(),
 		IsTableDriven:          res.GetProtoSpec().GetIsTableDriven(),
-		ExecutionTime:          res.GetExecutionTime(),
+		ExecutionTime:          duration(res.GetExecutionTime()),
 		ExecutionStatus:        pass,
 		PreHookMessages:        res.GetProtoSpec().GetPreHookMessages(),
 		PostHookMessages:       res.GetProtoSpec().GetPostHookMessages(),
@@ -378,9 +414,17 @@ func toSpec(res *gm.ProtoSpecResult, projectRoot string) *spec {
 		spec.Tags = append(spec.Tags, sourceTags...)
 	}
 	if hasParseErrors(res.Errors) {
-		spec.Errors = toErrors(res.Errors)
+		spec.Errors = toErrors(res.Errors, projectRoot)
 		return spec
 	}
+	lines := readSourceLines(res.GetProtoSpec().GetFileName())
+	spec.Source = newSource(projectRoot, normalizedSpecPath, specHeadingLine(lines))
+	firstScenarioLine := len(lines) + 1
+	for _, item := range res.GetProtoSpec().GetItems() {
+		if l := int(scenarioOf(item).GetSpan().GetStart()); l > 0 && l < firstScenarioLine {
+			firstScenarioLine = l
+		}
+	}
 	isTableScanned := false
 	for _, item := range res.GetProtoSpec().GetItems() {
 		switch item.GetItemType() {
@@ -395,6 +439,8 @@ func toSpec(res *gm.ProtoSpecResult, projectRoot string) *spec {
 			isTableScanned = true
 		case gm.ProtoItem_Scenario:
 			spec.Scenarios = append(spec.Scenarios, toScenario(item.GetScenario(), -1, nil))
+			spec.Scenarios[len(spec.Scenarios)-1].ExecutionIndex = len(spec.Scenarios) - 1
+			setScenarioSources(spec.Scenarios[len(spec.Scenarios)-1], item.GetScenario().GetSpan(), lines, spec.Source, firstScenarioLine)
 		case gm.ProtoItem_TableDrivenScenario:
 			tableDrivenScenario := item.GetTableDrivenScenario()
 			if tableDrivenScenario.GetIsScenarioTableDriven() && !tableDrivenScenario.GetIsSpecTableDriven() {
@@ -402,6 +448,8 @@ func toSpec(res *gm.ProtoSpecResult, projectRoot string) *spec {
 			} else {
 				spec.Scenarios = append(spec.Scenarios, toScenario(tableDrivenScenario.GetScenario(), int(item.TableDrivenScenario.GetTableRowIndex()), tableDrivenScenario))
 			}
+			spec.Scenarios[len(spec.Scenarios)-1].ExecutionIndex = len(spec.Scenarios) - 1
+			setScenarioSources(spec.Scenarios[len(spec.Scenarios)-1], tableDrivenScenario.GetScenario().GetSpan(), lines, spec.Source, firstScenarioLine)
 		}
 	}
 	for _, preHookFailure := range res.GetProtoSpec().GetPreHookFailures() {
@@ -419,10 +467,16 @@ func toSpec(res *gm.ProtoSpecResult, projectRoot string) *spec {
 	spec.PassedScenarioCount = p
 	spec.FailedScenarioCount = f
 	spec.SkippedScenarioCount = s
-	sort.Stable(bySceStatus(spec.Scenarios))
 	return spec
 }
 
+func scenarioOf(item *gm.ProtoItem) *gm.ProtoScenario {
+	if item.GetItemType() == gm.ProtoItem_TableDrivenScenario {
+		return item.GetTableDrivenScenario().GetScenario()
+	}
+	return item.GetScenario()
+}
+
 func computeScenarioStatistics(s *spec) (passed, failed, skipped int) {
 	for _, scn := range s.Scenarios {
 		switch scn.ExecutionStatus {
@@ -437,10 +491,10 @@ func computeScenarioStatistics(s *spec) (passed, failed, skipped int) {
 	return passed, failed, skipped
 }
 
-func toErrors(errors []*gm.Error) []buildError {
+func toErrors(errors []*gm.Error, projectRoot string) []buildError {
 	var buildErrors []buildError
 	for _, e := range errors {
-		err := buildError{FileName: e.Filename, LineNumber: int(e.LineNumber), Message: e.Message}
+		err := buildError{FileName: e.Filename, LineNumber: int(e.LineNumber), Message: e.Message, Source: newSource(projectRoot, e.Filename, int(e.LineNumber))}
 		switch e.Type {
 		case gm.Error_PARSE_ERROR:
 			err.ErrorType = parseErrorType
@@ -525,15 +579,15 @@ func computeScenarioTableStatuses(spec *spec) {
 }
 
 func toScenarioSummary(s *spec) *summary {
-	var sum = summary{Failed: s.FailedScenarioCount, Passed: s.PassedScenarioCount, Skipped: s.SkippedScenarioCount}
-	sum.Total = sum.Failed + sum.Passed + sum.Skipped
+	var sum = summary{Failed: s.FailedScenarioCount, Passed: s.PassedScenarioCount, Skipped: s.SkippedScenarioCount, Known: s.KnownIssueScenarioCount}
+	sum.Total = sum.Failed + sum.Passed + sum.Skipped + sum.Known
 	return &sum
 }
 
 func toScenario(scn *gm.ProtoScenario, tableRowIndex int, tableDrivenScenario *gm.ProtoTableDrivenScenario) *scenario {
 	scenario := &scenario{
 		Heading:                   scn.GetScenarioHeading(),
-		ExecutionTime:             formatTime(scn.GetExecutionTime()),
+		ExecutionTime:             duration(scn.GetExecutionTime()),
 		Tags:                      scn.GetTags(),
 		ExecutionStatus:           getScenarioStatus(scn),
 		Contexts:                  getItems(scn.GetContexts()),
@@ -582,7 +636,7 @@ func toStep(protoStep *gm.ProtoStep) *step {
 		Status:                getStepStatus(protoStep.GetStepExecutionResult()),
 		StackTrace:            res.GetStackTrace(),
 		ErrorMessage:          res.GetErrorMessage(),
-		ExecutionTime:         formatTime(res.GetExecutionTime()),
+		ExecutionTime:         duration(res.GetExecutionTime()),
 		Messages:              res.GetMessage(),
 		FailureScreenshotFile: failureScreenshotFile,
 		FailureScreenshot:     base64.StdEncoding.EncodeToString(res.GetFailureScreenshot()), //nolint - deprecated, but read here for backward compatibility
@@ -742,7 +796,3 @@ func getScenarioStatus(scn *gm.ProtoScenario) status {
 		return notExecuted
 	}
 }
-
-func formatTime(ms int64) string {
-	return time.Unix(0, ms*int64(time.Millisecond)).UTC().Format(execTimeFormat)
-}
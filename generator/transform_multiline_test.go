@@ -134,7 +134,7 @@ func TestToStepWithMultilineStringParameter(t *testing.T) {
 		},
 		Result: &result{
 			Status:        pass,
-			ExecutionTime: "00:03:31",
+			ExecutionTime: 211316,
 		},
 	}
 
@@ -152,7 +152,7 @@ func TestToStepWithMixedMultilineAndSingleLine(t *testing.T) {
 		},
 		Result: &result{
 			Status:        pass,
-			ExecutionTime: "00:03:31",
+			ExecutionTime: 211316,
 		},
 	}
 
@@ -168,7 +168,7 @@ func TestToStepWithEmptyMultilineString(t *testing.T) {
 		},
 		Result: &result{
 			Status:        pass,
-			ExecutionTime: "00:03:31",
+			ExecutionTime: 211316,
 		},
 	}
 
@@ -508,12 +508,13 @@ func TestToOverview(t *testing.T) {
 		Env:              "ci-java",
 		Tags:             "!unimplemented",
 		SuccessRate:      80.00,
-		ExecutionTime:    "00:01:53",
+		ExecutionTime:    113163,
 		Timestamp:        "Jun 3, 2016 at 12:29pm",
 		Summary:          &summary{Total: 15, Failed: 2, Passed: 8, Skipped: 5},
 		ScenarioSummary:  &summary{Total: 23, Failed: 6, Passed: 7, Skipped: 10},
 		PreHookMessages:  []string{"Before Suite Message"},
 		PostHookMessages: []string{"After Suite Message"},
+		ColorScheme:      "auto",
 	}
 
 	got := toOverview(suiteRes1, "")
@@ -525,10 +526,11 @@ func TestToSidebar(t *testing.T) {
 	want := &sidebar{
 		IsBeforeHookFailure: false,
 		Specs: []*specsMeta{
-			newSpecsMeta("specRes2", "00:03:31", true, false, []string{"tag1", "tag2", "tag3"}, "specRes2.html"),
-			newSpecsMeta("specRes3", "00:03:31", false, true, []string{"tag1"}, "specRes3.html"),
-			newSpecsMeta("specRes1", "00:03:31", false, false, []string{"tag1", "tag2"}, "foobar.html"),
+			withIndexes(newSpecsMeta("specRes2", 211316, true, false, []string{"tag1", "tag2", "tag3"}, "specRes2.html"), 1, 1),
+			withIndexes(newSpecsMeta("specRes3", 211316, false, true, []string{"tag1"}, "specRes3.html"), 2, 2),
+			newSpecsMeta("specRes1", 211316, false, false, []string{"tag1", "tag2"}, "foobar.html"),
 		},
+		Order: statusOrder,
 	}
 
 	got := toSidebar(suiteRes2, "")
@@ -540,7 +542,7 @@ func TestToSidebar(t *testing.T) {
 func TestToSpecHeader(t *testing.T) {
 	want := &specHeader{
 		SpecName:      "specRes1",
-		ExecutionTime: "00:03:31",
+		ExecutionTime: 211316,
 		FileName:      "/tmp/gauge/specs/foobar.spec",
 		Tags:          []string{"tag1", "tag2"},
 		Summary:       &summary{},
@@ -569,6 +571,7 @@ func TestToSpec(t *testing.T) {
 		ExecutionTime:          211316,
 		PreHookMessages:        []string{"Before Spec Hook Message"},
 		PostHookMessages:       []string{"After Spec Hook Message"},
+		Source:                 &source{Path: "specs/foobar.spec", AbsPath: filepath.Join(string(os.PathSeparator), "tmp", "gauge", "specs", "foobar.spec")},
 	}
 
 	got := toSpec(specRes1, "/tmp/gauge/")
@@ -597,6 +600,13 @@ func TestToSpecWithScenariosInOrder(t *testing.T) {
 	if len(got.Scenarios) != 5 {
 		t.Errorf("want:%d\ngot:%d\n", 5, len(got.Scenarios))
 	}
+	for i, s := range got.Scenarios {
+		if s.ExecutionIndex != i {
+			t.Errorf("want:%d\ngot:%d\n", i, s.ExecutionIndex)
+		}
+	}
+
+	sortScenarios(got.Scenarios, statusOrder)
 	if got.Scenarios[0].ExecutionStatus != fail {
 		t.Errorf("want:%q\ngot:%q\n", fail, got.Scenarios[0].ExecutionStatus)
 	}
@@ -636,8 +646,8 @@ func TestToSpecWithErrors(t *testing.T) {
 
 	want := &spec{
 		Errors: []buildError{
-			{FileName: "fileName", LineNumber: 2, Message: "message", ErrorType: parseErrorType},
-			{FileName: "fileName1", LineNumber: 4, Message: "message1", ErrorType: validationErrorType},
+			{FileName: "fileName", LineNumber: 2, Message: "message", ErrorType: parseErrorType, Source: &source{Path: "fileName", AbsPath: "fileName", Line: 2}},
+			{FileName: "fileName1", LineNumber: 4, Message: "message1", ErrorType: validationErrorType, Source: &source{Path: "fileName1", AbsPath: "fileName1", Line: 4}},
 		},
 		Scenarios:       make([]*scenario, 0),
 		FileName:        "spec-file-1.spec",
@@ -665,13 +675,13 @@ func TestToSpecForTableDrivenSpec(t *testing.T) {
 		Scenarios: []*scenario{
 			{
 				Heading:       "Scenario 1",
-				ExecutionTime: "00:00:00",
+				ExecutionTime: 0,
 				Items: []item{
 					{
 						Kind: stepKind,
 						Step: &step{
 							Fragments: []*fragment{{FragmentKind: textFragmentKind, Text: "Step1"}},
-							Result:    &result{Status: fail, ExecutionTime: "00:03:31"},
+							Result:    &result{Status: fail, ExecutionTime: 211316},
 						},
 					},
 				},
@@ -684,13 +694,13 @@ func TestToSpecForTableDrivenSpec(t *testing.T) {
 			},
 			{
 				Heading:       "Scenario 1",
-				ExecutionTime: "00:00:00",
+				ExecutionTime: 0,
 				Items: []item{
 					{
 						Kind: stepKind,
 						Step: &step{
 							Fragments: []*fragment{{FragmentKind: textFragmentKind, Text: "Step1"}},
-							Result:    &result{Status: pass, ExecutionTime: "00:03:31"},
+							Result:    &result{Status: pass, ExecutionTime: 211316},
 						},
 					},
 				},
@@ -700,6 +710,7 @@ func TestToSpecForTableDrivenSpec(t *testing.T) {
 				TableRowIndex:             1,
 				BeforeScenarioHookFailure: nil,
 				AfterScenarioHookFailure:  nil,
+				ExecutionIndex:            1,
 			},
 		},
 		BeforeSpecHookFailures: nil,
@@ -708,6 +719,7 @@ func TestToSpecForTableDrivenSpec(t *testing.T) {
 		PassedScenarioCount:    1,
 		FailedScenarioCount:    1,
 		SkippedScenarioCount:   0,
+		Source:                 &source{Path: "specs/foobar.spec", AbsPath: filepath.Join(string(os.PathSeparator), "tmp", "gauge", "specs", "foobar.spec")},
 	}
 
 	got := toSpec(datatableDrivenSpec, "/tmp/gauge/")
@@ -745,6 +757,7 @@ func TestToSpecWithHookFailure(t *testing.T) {
 		FileName:               "specfile-1.spec",
 		ExecutionStatus:        skip,
 		ExecutionTime:          211316,
+		Source:                 &source{Path: "specfile-1.spec", AbsPath: "specfile-1.spec"},
 	}
 
 	got := toSpec(specResWithSpecHookFailure, "")
@@ -1012,7 +1025,7 @@ func TestToScenarioSummary(t *testing.T) {
 func TestToScenario(t *testing.T) {
 	want := &scenario{
 		Heading:          "Vowel counts in single word",
-		ExecutionTime:    "00:01:53",
+		ExecutionTime:    113163,
 		ExecutionStatus:  pass,
 		Tags:             []string{"foo", "bar"},
 		PreHookMessages:  []string{"Before Scenario Message"},
@@ -1022,14 +1035,14 @@ func TestToScenario(t *testing.T) {
 				Kind: stepKind,
 				Step: &step{
 					Fragments: []*fragment{{FragmentKind: textFragmentKind, Text: "Context Step1"}},
-					Result:    &result{Status: pass, ExecutionTime: "00:03:31"},
+					Result:    &result{Status: pass, ExecutionTime: 211316},
 				},
 			},
 			{
 				Kind: stepKind,
 				Step: &step{
 					Fragments: []*fragment{{FragmentKind: textFragmentKind, Text: "Context Step2"}},
-					Result:    &result{Status: fail, ExecutionTime: "00:03:31"},
+					Result:    &result{Status: fail, ExecutionTime: 211316},
 				},
 			},
 		},
@@ -1042,7 +1055,7 @@ func TestToScenario(t *testing.T) {
 				Kind: stepKind,
 				Step: &step{
 					Fragments: []*fragment{{FragmentKind: textFragmentKind, Text: "Step1"}},
-					Result:    &result{Status: fail, ExecutionTime: "00:03:31"},
+					Result:    &result{Status: fail, ExecutionTime: 211316},
 				},
 			},
 			{
@@ -1053,7 +1066,7 @@ func TestToScenario(t *testing.T) {
 				Kind: stepKind,
 				Step: &step{
 					Fragments: []*fragment{{FragmentKind: textFragmentKind, Text: "Step2"}},
-					Result:    &result{Status: pass, ExecutionTime: "00:03:31"},
+					Result:    &result{Status: pass, ExecutionTime: 211316},
 				},
 			},
 			{
@@ -1066,14 +1079,14 @@ func TestToScenario(t *testing.T) {
 				Kind: stepKind,
 				Step: &step{
 					Fragments: []*fragment{{FragmentKind: textFragmentKind, Text: "Teardown Step1"}},
-					Result:    &result{Status: pass, ExecutionTime: "00:03:31"},
+					Result:    &result{Status: pass, ExecutionTime: 211316},
 				},
 			},
 			{
 				Kind: stepKind,
 				Step: &step{
 					Fragments: []*fragment{{FragmentKind: textFragmentKind, Text: "Teardown Step2"}},
-					Result:    &result{Status: fail, ExecutionTime: "00:03:31"},
+					Result:    &result{Status: fail, ExecutionTime: 211316},
 				},
 			},
 		},
@@ -1091,7 +1104,7 @@ func TestToScenarioWithHookFailures(t *testing.T) {
 	screenShot := "Screenshot.png"
 	want := &scenario{
 		Heading:         "Vowel counts in single word",
-		ExecutionTime:   "00:01:53",
+		ExecutionTime:   113163,
 		ExecutionStatus: fail,
 		Contexts:        []item{},
 		Items: []item{
@@ -1099,7 +1112,7 @@ func TestToScenarioWithHookFailures(t *testing.T) {
 				Kind: stepKind,
 				Step: &step{
 					Fragments: []*fragment{{FragmentKind: textFragmentKind, Text: "Step1"}},
-					Result:    &result{Status: fail, ExecutionTime: "00:03:31"},
+					Result:    &result{Status: fail, ExecutionTime: 211316},
 				},
 			},
 		},
@@ -1127,7 +1140,7 @@ func TestToConcept(t *testing.T) {
 					},
 				},
 			},
-			Result: &result{Status: pass, ExecutionTime: "00:03:31"},
+			Result: &result{Status: pass, ExecutionTime: 211316},
 		},
 		Items: []item{
 			{
@@ -1138,14 +1151,14 @@ func TestToConcept(t *testing.T) {
 							{FragmentKind: textFragmentKind, Text: "Tell "},
 							{FragmentKind: dynamicFragmentKind, Text: "hello"},
 						},
-						Result: &result{Status: pass, ExecutionTime: "00:03:31"},
+						Result: &result{Status: pass, ExecutionTime: 211316},
 					},
 					Items: []item{
 						{
 							Kind: stepKind,
 							Step: &step{
 								Fragments: []*fragment{{FragmentKind: textFragmentKind, Text: "Say Hi"}},
-								Result:    &result{Status: pass, ExecutionTime: "00:03:31"},
+								Result:    &result{Status: pass, ExecutionTime: 211316},
 							},
 						},
 					},
@@ -1166,7 +1179,7 @@ func TestToConcept(t *testing.T) {
 							},
 						},
 					},
-					Result: &result{Status: pass, ExecutionTime: "00:03:31"},
+					Result: &result{Status: pass, ExecutionTime: 211316},
 				},
 			},
 		},
@@ -1192,7 +1205,7 @@ func TestToStep(t *testing.T) {
 				},
 			},
 		},
-		Result: &result{Status: skip, ExecutionTime: "00:03:31", SkippedReason: "Step impl not found"},
+		Result: &result{Status: skip, ExecutionTime: 211316, SkippedReason: "Step impl not found"},
 	}
 
 	got := toStep(protoStep)
@@ -1217,7 +1230,7 @@ func TestToStepCollectsScreenshot(t *testing.T) {
 		},
 		Result: &result{
 			Status:          skip,
-			ExecutionTime:   "00:03:31",
+			ExecutionTime:   211316,
 			SkippedReason:   "Step impl not found",
 			ScreenshotFiles: []string{"screenshot1.png", "screenshot2.png"},
 		},
@@ -1262,7 +1275,7 @@ Mingle,2`,
 		},
 		Result: &result{
 			Status:        pass,
-			ExecutionTime: "00:03:31",
+			ExecutionTime: 211316,
 		},
 	}
 
@@ -1280,7 +1293,7 @@ func TestToStepWithAfterHookFailure(t *testing.T) {
 		},
 		Result: &result{
 			Status:        fail,
-			ExecutionTime: "00:03:31",
+			ExecutionTime: 211316,
 		},
 		AfterStepHookFailure: newHookFailure("", "After Step", "err", screenShot, "Stacktrace"),
 	}
@@ -1725,7 +1738,7 @@ func TestToNestedSuiteResultMapsAfterSuiteHookFailure(t *testing.T) {
 }
 
 func TestToNestedSuiteResultMapsExecutionTime(t *testing.T) {
-	want := int64(2000)
+	want := duration(2000)
 	sr := &SuiteResult{SpecResults: []*spec{
 		{FileName: "nested1/foo.spec", SpecHeading: "Foo Spec", ExecutionTime: 1000},
 		{FileName: "nested1/nested2/boo.spec", SpecHeading: "Boo Spec", ExecutionTime: 1000},
@@ -1821,6 +1834,14 @@ func TestToNestedSuiteResultMapsSpecResults(t *testing.T) {
 	checkEqual(t, "", want, got.SpecResults)
 }
 
+func TestToNestedSuiteResultLeavesOutSiblingFoldersSharingItsPrefix(t *testing.T) {
+	fooSpec := spec{FileName: "nested1/foo.spec", SpecHeading: "Foo Spec"}
+	quxSpec := spec{FileName: "nested10/qux.spec", SpecHeading: "Qux Spec"}
+	got := toNestedSuiteResult("nested1", &SuiteResult{SpecResults: []*spec{&fooSpec, &quxSpec}})
+
+	checkEqual(t, "", []*spec{&fooSpec}, got.SpecResults)
+}
+
 func TestToSuiteResultMapsSpecResultsGreaterThanBufferSize(t *testing.T) {
 	psr := &gm.ProtoSuiteResult{
 		SpecResults: []*gm.ProtoSpecResult{
@@ -0,0 +1,95 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// specsDir is a folder of specs in the sidebar of a report with an index page per folder.
+type specsDir struct {
+	Name string
+	// IndexFile is the index page of the folder, relative to the page of the sidebar
+	IndexFile string
+	// Passed, Failed, Skipped and Known count the specs of the folder and of its sub folders
+	Passed  int
+	Failed  int
+	Skipped int
+	Known   int
+	// Open is set for the folders with failures and those holding the spec of the page
+	Open  bool
+	Dirs  []*specsDir
+	Specs []*specsMeta
+	path  string
+}
+
+func (d *specsDir) dir(name string) *specsDir {
+	for _, c := range d.Dirs {
+		if c.Name == name {
+			return c
+		}
+	}
+	c := &specsDir{Name: name, path: filepath.Join(d.path, name), Dirs: make([]*specsDir, 0), Specs: make([]*specsMeta, 0)}
+	d.Dirs = append(d.Dirs, c)
+	return c
+}
+
+func (d *specsDir) count(sm *specsMeta) {
+	switch {
+	case sm.Known:
+		d.Known++
+	case sm.Failed:
+		d.Failed++
+	case sm.Skipped:
+		d.Skipped++
+	default:
+		d.Passed++
+	}
+}
+
+// toSpecsTree groups the specs of a suite by the folders of their files, starting from the folder of the suite.
+// specsMetaList holds the sidebar entries of the specs, in the same order. The specs of each folder are sorted in
+// the given order, its sub folders by name.
+func toSpecsTree(res *SuiteResult, specsMetaList []*specsMeta, specFilePath, basePath, order string) *specsDir {
+	root := &specsDir{path: res.BasePath, Dirs: make([]*specsDir, 0), Specs: make([]*specsMeta, 0)}
+	rootDir := filepath.Join(projectRoot, res.BasePath)
+	for i, s := range res.SpecResults {
+		d := root
+		rel, err := filepath.Rel(rootDir, filepath.Dir(s.FileName))
+		if err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			for _, name := range strings.Split(rel, string(filepath.Separator)) {
+				d = d.dir(name)
+				d.count(specsMetaList[i])
+				if s.FileName == specFilePath {
+					d.Open = true
+				}
+			}
+		}
+		d.Specs = append(d.Specs, specsMetaList[i])
+	}
+	root.finish(basePath, order)
+	return root
+}
+
+func (d *specsDir) finish(basePath, order string) {
+	sortSpecsMeta(d.Specs, order)
+	sort.SliceStable(d.Dirs, func(i, j int) bool { return strings.ToLower(d.Dirs[i].Name) < strings.ToLower(d.Dirs[j].Name) })
+	for _, c := range d.Dirs {
+		c.IndexFile = toIndexFileName(c.path, basePath)
+		c.Open = c.Open || c.Failed > 0
+		c.finish(basePath, order)
+	}
+}
+
+func toIndexFileName(dir, basePath string) string {
+	p, err := filepath.Rel(basePath, filepath.Join(projectRoot, dir, "index.html"))
+	if err != nil {
+		p = filepath.Join(dir, "index.html")
+	}
+	return filepath.ToSlash(p)
+}
@@ -0,0 +1,115 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func treeSuite() *SuiteResult {
+	return &SuiteResult{hasNestedSpecs: true, SpecResults: []*spec{
+		{SpecHeading: "Login", FileName: "/project/specs/auth/login.spec", ExecutionStatus: pass},
+		{SpecHeading: "Pay", FileName: "/project/specs/checkout/pay.spec", ExecutionStatus: fail},
+		{SpecHeading: "Logout", FileName: "/project/specs/auth/logout.spec", ExecutionStatus: skip},
+		{SpecHeading: "Refund", FileName: "/project/specs/checkout/refund/refund.spec", ExecutionStatus: fail, HasOnlyKnownIssues: true},
+		{SpecHeading: "Smoke", FileName: "/project/smoke.spec", ExecutionStatus: pass},
+	}}
+}
+
+func specNames(specs []*specsMeta) []string {
+	names := make([]string, 0)
+	for _, s := range specs {
+		names = append(names, s.SpecName)
+	}
+	return names
+}
+
+func dirNames(dirs []*specsDir) []string {
+	names := make([]string, 0)
+	for _, d := range dirs {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+func TestToSidebarGroupsNestedSpecsByFolder(t *testing.T) {
+	oldProjectRoot := projectRoot
+	projectRoot = "/project"
+	defer func() { projectRoot = oldProjectRoot }()
+
+	tree := toSidebar(treeSuite(), "").Tree
+
+	checkEqual(t, "", []string{"Smoke"}, specNames(tree.Specs))
+	checkEqual(t, "", []string{"specs"}, dirNames(tree.Dirs))
+	specs := tree.Dirs[0]
+	checkEqual(t, "", "specs/index.html", specs.IndexFile)
+	checkEqual(t, "", []int{1, 1, 1, 1}, []int{specs.Passed, specs.Failed, specs.Skipped, specs.Known})
+	checkEqual(t, "", []string{"auth", "checkout"}, dirNames(specs.Dirs))
+	auth, checkout := specs.Dirs[0], specs.Dirs[1]
+	checkEqual(t, "", []string{"Logout", "Login"}, specNames(auth.Specs))
+	checkEqual(t, "", false, auth.Open)
+	checkEqual(t, "", true, checkout.Open)
+	checkEqual(t, "", "specs/checkout/refund/index.html", checkout.Dirs[0].IndexFile)
+	checkEqual(t, "", "specs/checkout/refund/refund.html", checkout.Dirs[0].Specs[0].ReportFile)
+}
+
+func TestSpecsTreeOfSpecPageLinksRelativeToItAndOpensItsFolders(t *testing.T) {
+	oldProjectRoot := projectRoot
+	projectRoot = "/project"
+	defer func() { projectRoot = oldProjectRoot }()
+
+	tree := toSidebar(treeSuite(), "/project/specs/auth/login.spec").Tree
+
+	auth := tree.Dirs[0].Dirs[0]
+	checkEqual(t, "", "../index.html", tree.Dirs[0].IndexFile)
+	checkEqual(t, "", "index.html", auth.IndexFile)
+	checkEqual(t, "", true, tree.Dirs[0].Open)
+	checkEqual(t, "", true, auth.Open)
+	checkEqual(t, "", "login.html", auth.Specs[1].ReportFile)
+}
+
+func TestSpecsTreeOfNestedIndexPageStartsFromItsFolder(t *testing.T) {
+	oldProjectRoot := projectRoot
+	projectRoot = "/project"
+	defer func() { projectRoot = oldProjectRoot }()
+
+	tree := toSidebar(toNestedSuiteResult(filepath.Join("specs", "checkout"), treeSuite()), "").Tree
+
+	checkEqual(t, "", []string{"Pay"}, specNames(tree.Specs))
+	checkEqual(t, "", []string{"refund"}, dirNames(tree.Dirs))
+	checkEqual(t, "", "refund/index.html", tree.Dirs[0].IndexFile)
+}
+
+func TestSidebarHasNoTreeWithoutNestedSpecs(t *testing.T) {
+	res := treeSuite()
+	res.hasNestedSpecs = false
+
+	if got := toSidebar(res, "").Tree; got != nil {
+		t.Errorf("Expected no tree, got %v", got)
+	}
+}
+
+func TestGenerateIndexPagesForEveryFolderOfSpecs(t *testing.T) {
+	readTemplates(templateBasePath)
+	oldProjectRoot := projectRoot
+	projectRoot = "/project"
+	defer func() { projectRoot = oldProjectRoot }()
+	dir := t.TempDir()
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	generateIndexPages(treeSuite(), dir, &wg)
+	wg.Wait()
+
+	for _, d := range []string{"specs", "specs/auth", "specs/checkout", "specs/checkout/refund"} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(d), "index.html")); err != nil {
+			t.Errorf("Expected an index page for %s: %s", d, err.Error())
+		}
+	}
+}
@@ -11,12 +11,14 @@ import (
 	"os"
 
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/html-report/generator"
 	"google.golang.org/grpc"
 )
 
 type handler struct {
 	gauge_messages.UnimplementedReporterServer
-	server *grpc.Server
+	server   *grpc.Server
+	timeline *generator.Timeline
 }
 
 // NotifyConceptExecutionEnding implements gauge_messages.ReporterServer.
@@ -33,9 +35,11 @@ func (h *handler) NotifyExecutionStarting(c context.Context, m *gauge_messages.E
 	return &gauge_messages.Empty{}, nil
 }
 func (h *handler) NotifySpecExecutionStarting(c context.Context, m *gauge_messages.SpecExecutionStartingRequest) (*gauge_messages.Empty, error) {
+	h.timeline.SpecStarting(m.GetStream(), m.GetCurrentExecutionInfo())
 	return &gauge_messages.Empty{}, nil
 }
 func (h *handler) NotifyScenarioExecutionStarting(c context.Context, m *gauge_messages.ScenarioExecutionStartingRequest) (*gauge_messages.Empty, error) {
+	h.timeline.ScenarioStarting(m.GetStream(), m.GetCurrentExecutionInfo())
 	return &gauge_messages.Empty{}, nil
 }
 func (h *handler) NotifyStepExecutionStarting(c context.Context, m *gauge_messages.StepExecutionStartingRequest) (*gauge_messages.Empty, error) {
@@ -45,9 +49,11 @@ func (h *handler) NotifyStepExecutionEnding(c context.Context, m *gauge_messages
 	return &gauge_messages.Empty{}, nil
 }
 func (h *handler) NotifyScenarioExecutionEnding(c context.Context, m *gauge_messages.ScenarioExecutionEndingRequest) (*gauge_messages.Empty, error) {
+	h.timeline.ScenarioEnding(m.GetStream(), m.GetCurrentExecutionInfo(), m.GetScenarioResult())
 	return &gauge_messages.Empty{}, nil
 }
 func (h *handler) NotifySpecExecutionEnding(c context.Context, m *gauge_messages.SpecExecutionEndingRequest) (*gauge_messages.Empty, error) {
+	h.timeline.SpecEnding(m.GetStream(), m.GetCurrentExecutionInfo(), m.GetSpecResult())
 	return &gauge_messages.Empty{}, nil
 }
 func (h *handler) NotifyExecutionEnding(c context.Context, m *gauge_messages.ExecutionEndingRequest) (*gauge_messages.Empty, error) {
@@ -55,7 +61,7 @@ func (h *handler) NotifyExecutionEnding(c context.Context, m *gauge_messages.Exe
 }
 
 func (h *handler) NotifySuiteResult(c context.Context, m *gauge_messages.SuiteExecutionResult) (*gauge_messages.Empty, error) {
-	createReport(m, true)
+	createReport(m, h.timeline, true)
 	return &gauge_messages.Empty{}, nil
 }
 
@@ -27,7 +27,7 @@ const (
 	setupAction     = "setup"
 	executionAction = "execution"
 	pluginActionEnv = "html-report_action"
-	timeFormat      = "2006-01-02_15.04.05"
+	timeFormat      = env.TimeStampedReportFormat
 )
 
 type nameGenerator interface {
@@ -43,19 +43,29 @@ func (T timeStampedNameGenerator) randomName() string {
 
 var pluginsDir string
 
-func createReport(suiteResult *gauge_messages.SuiteExecutionResult, searchIndex bool) {
+func createReport(suiteResult *gauge_messages.SuiteExecutionResult, timeline *generator.Timeline, searchIndex bool) {
 	projectRoot, err := common.GetProjectRoot()
 	if err != nil {
 		logger.Debugf("Failed to generate report. %s", err.Error())
 		return
 	}
-	reportsDir := getReportsDirectory(getNameGen())
+	nameGen := getNameGen()
+	reportsDir := getReportsDirectory(nameGen)
 	res := generator.ToSuiteResult(projectRoot, suiteResult.GetSuiteResult())
+	res.Timeline = timeline
+	res.Metadata = generator.ReadMetadata(projectRoot)
+	generator.AssignOwners(res, projectRoot)
+	generator.MarkKnownIssues(res, projectRoot)
 	logger.Debug("Transformed SuiteResult to report structure")
-	go createReportExecutableFile(getExecutableAndTargetPath(reportsDir, pluginsDir))
 	t := theme.GetThemePath(pluginsDir)
 	generator.GenerateReport(res, reportsDir, t, searchIndex)
 	logger.Debugf("Done generating HTML report using theme from %s", t)
+	createReportExecutableFile(getExecutableAndTargetPath(reportsDir, pluginsDir))
+	if nameGen != nil {
+		reportsRoot, current := filepath.Split(reportsDir)
+		updateLatestReport(reportsRoot, current)
+		cleanupOldReports(reportsRoot, current, env.ReportsRetentionCount(), env.ReportsRetentionPeriod())
+	}
 }
 
 func getNameGen() nameGenerator {
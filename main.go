@@ -14,6 +14,7 @@ import (
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
 	"github.com/getgauge/html-report/env"
+	"github.com/getgauge/html-report/generator"
 	"github.com/getgauge/html-report/logger"
 	"github.com/getgauge/html-report/regenerate"
 	"google.golang.org/grpc"
@@ -23,10 +24,23 @@ const usage = `Usage of using_flag:
   -i, --input Source file to generate report from. This should be generated in <PROJECTROOT>/.gauge folder.
   -o, --output Output location for generating report. Will create directory if it doesn't exist.
   -t, --theme Theme to use for generating html report. 'default' theme will be used if not specified.
+  -r, --rerun Result of a rerun of failed specs (gauge run --failed) to overlay onto the input. Should be generated in <PROJECTROOT>/.gauge folder.
+  --exit-code Exits with code 1 if the result has failures other than its known issues.
+  --min-success-rate Exits with code 1 if the success rate of the result, in percent, is lower.
+  --max-failed-scenarios Exits with code 1 if more scenarios failed, not counting known issues.
+  --critical-tag Exits with code 1 if a specification or scenario with this tag failed, e.g. critical.
+  --max-duration Exits with code 1 if the suite took longer, e.g. 45m.
   -h, --help prints help information 
+
+  theme init <dir>  Copies the default theme to <dir> as a starting point for a custom theme.
+  theme check <dir> Renders the theme at <dir> against a sample result and reports missing templates and errors.
 `
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "theme" {
+		runThemeCommand(os.Args[2:])
+		return
+	}
 	var inputFile string
 	flag.StringVar(&inputFile, "input", "", "Source file to generate report from. This should be generated in <PROJECTROOT>/.gauge folder.")
 	flag.StringVar(&inputFile, "i", "", "Source file to generate report from. This should be generated in <PROJECTROOT>/.gauge folder.")
@@ -36,6 +50,16 @@ func main() {
 	var themePath string
 	flag.StringVar(&themePath, "theme", "", "Theme to use for generating html report. 'default' theme will be used if not specified.")
 	flag.StringVar(&themePath, "t", "", "Theme to use for generating html report. 'default' theme will be used if not specified.")
+	var rerunFile string
+	flag.StringVar(&rerunFile, "rerun", "", "Result of a rerun of failed specs (gauge run --failed) to overlay onto the input.")
+	flag.StringVar(&rerunFile, "r", "", "Result of a rerun of failed specs (gauge run --failed) to overlay onto the input.")
+	var exitCode bool
+	flag.BoolVar(&exitCode, "exit-code", false, "Exits with code 1 if the result has failures other than its known issues.")
+	var gates generator.QualityGates
+	flag.Float64Var(&gates.MinSuccessRate, "min-success-rate", 0, "Exits with code 1 if the success rate of the result, in percent, is lower.")
+	flag.IntVar(&gates.MaxFailedScenarios, "max-failed-scenarios", -1, "Exits with code 1 if more scenarios failed, not counting known issues.")
+	flag.StringVar(&gates.CriticalTag, "critical-tag", "", "Exits with code 1 if a specification or scenario with this tag failed, e.g. critical.")
+	flag.DurationVar(&gates.MaxDuration, "max-duration", 0, "Exits with code 1 if the suite took longer, e.g. 45m.")
 
 	flag.Usage = func() { fmt.Print(usage) }
 	flag.Parse()
@@ -51,7 +75,24 @@ func main() {
 		if !common.FileExists(inputFile) {
 			logger.Fatalf("Input file does not exist: %s", inputFile)
 		}
-		regenerate.Report(inputFile, outDir, themePath, projectRoot)
+		var res *generator.SuiteResult
+		if rerunFile != "" {
+			if !common.FileExists(rerunFile) {
+				logger.Fatalf("Rerun file does not exist: %s", rerunFile)
+			}
+			res = regenerate.RerunReport(inputFile, rerunFile, outDir, themePath, projectRoot)
+		} else {
+			res = regenerate.Report(inputFile, outDir, themePath, projectRoot)
+		}
+		if violations := gates.Violations(res); len(violations) > 0 {
+			for _, v := range violations {
+				logger.Warnf("%s", v)
+			}
+			logger.Fatalf("Quality gates failed, %d threshold(s) violated", len(violations))
+		}
+		if exitCode && res.HasUnknownFailures() {
+			os.Exit(1)
+		}
 		return
 	}
 
@@ -76,7 +117,7 @@ func main() {
 		mSize := env.GetMaxMessageSize()
 		logger.Debugf("Setting MaxRecvMsgSize = %d MB", mSize)
 		server := grpc.NewServer(grpc.MaxRecvMsgSize(mSize * 1024 * 1024))
-		h := &handler{server: server}
+		h := &handler{server: server, timeline: generator.NewTimeline()}
 		gauge_messages.RegisterReporterServer(server, h)
 		logger.Infof("Listening on port:%d", l.Addr().(*net.TCPAddr).Port)
 		err = server.Serve(l)
@@ -7,7 +7,6 @@ package regenerate
 
 import (
 	"os"
-	"path/filepath"
 
 	"github.com/getgauge/html-report/logger"
 
@@ -18,8 +17,27 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-// Report generates html report from saved result.
-func Report(inputFile, reportsDir, themePath, pRoot string) {
+// Report generates html report from saved result, and returns the result the report was generated from.
+func Report(inputFile, reportsDir, themePath, pRoot string) *generator.SuiteResult {
+	res := generator.ToSuiteResult(pRoot, readSuiteResult(inputFile))
+	generator.AssignOwners(res, pRoot)
+	generator.MarkKnownIssues(res, pRoot)
+	generateReport(res, reportsDir, themePath)
+	return res
+}
+
+// RerunReport generates html report from a saved result, overlaid with the saved result of a rerun of its failed specs.
+func RerunReport(inputFile, rerunFile, reportsDir, themePath, pRoot string) *generator.SuiteResult {
+	original := generator.ToSuiteResult(pRoot, readSuiteResult(inputFile))
+	rerun := generator.ToSuiteResult(pRoot, readSuiteResult(rerunFile))
+	res := generator.MergeRerun(original, rerun)
+	generator.AssignOwners(res, pRoot)
+	generator.MarkKnownIssues(res, pRoot)
+	generateReport(res, reportsDir, themePath)
+	return res
+}
+
+func readSuiteResult(inputFile string) *gauge_messages.ProtoSuiteResult {
 	b, err := os.ReadFile(inputFile)
 	if err != nil {
 		logger.Fatal(err.Error())
@@ -29,12 +47,13 @@ func Report(inputFile, reportsDir, themePath, pRoot string) {
 	if err != nil {
 		logger.Fatalf("Unable to read last run data from %s. Error: %s", inputFile, err.Error())
 	}
-	res := generator.ToSuiteResult(pRoot, psr)
+	return psr
+}
 
+func generateReport(res *generator.SuiteResult, reportsDir, themePath string) {
 	env.CreateDirectory(reportsDir)
 	if themePath == "" {
-		workingDir, _ := env.GetCurrentExecutableDir()
-		themePath = theme.GetDefaultThemePath(filepath.Dir(workingDir))
+		themePath = theme.PluginDefaultThemePath()
 	}
 	generator.GenerateReport(res, reportsDir, themePath, true)
 }
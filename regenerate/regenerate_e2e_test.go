@@ -39,7 +39,7 @@ func setup() {
 func TestEndToEndHTMLGenerationFromSavedResult(t *testing.T) {
 	setup()
 	expectedFiles := []string{"index.html", "specs/example.html", "js/search_index.js"}
-	reportDir := filepath.Join("_testdata", "e2e")
+	reportDir := t.TempDir()
 	inputFile := filepath.Join("_testdata", "last_run_result")
 
 	Report(inputFile, reportDir, templateBasePath, "/tmp/foo/")
@@ -56,19 +56,15 @@ func TestEndToEndHTMLGenerationFromSavedResult(t *testing.T) {
 		want := helper.RemoveNewline(string(wantContent))
 		helper.AssertEqual(want, got, expectedFile, t)
 	}
-	cleanUp(t, reportDir)
 }
 
-func cleanUp(t *testing.T, reportDir string) {
-	s, err := filepath.Glob(filepath.Join(reportDir, "*"))
-	if err != nil {
-		t.Error(err)
-	}
-	for _, f := range s {
-		if f != filepath.Join(reportDir, ".gitkeep") {
-			if err := os.RemoveAll(f); err != nil {
-				return // error removing file
-			}
-		}
+func TestRegeneratedReportHasNoMetadataOfTheCurrentExecution(t *testing.T) {
+	setup()
+	t.Setenv("html_report_metadata_build_number", "1024")
+
+	res := Report(filepath.Join("_testdata", "last_run_result"), t.TempDir(), templateBasePath, "/tmp/foo/")
+
+	if len(res.Metadata) != 0 {
+		t.Errorf("Expected no metadata, got %v", res.Metadata)
 	}
 }
@@ -0,0 +1,181 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/html-report/env"
+	"github.com/getgauge/html-report/generator"
+	"github.com/getgauge/html-report/logger"
+)
+
+const (
+	latestReportLink     = env.LatestReportLink
+	latestReportRedirect = env.LatestReportRedirect
+)
+
+type timeStampedReport struct {
+	name    string
+	created time.Time
+}
+
+// timeStampedReports lists the report directories in reportsRoot whose names were produced by timeStampedNameGenerator,
+// oldest first. Anything else in the directory is left alone.
+func timeStampedReports(reportsRoot string) ([]timeStampedReport, error) {
+	entries, err := os.ReadDir(reportsRoot)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]timeStampedReport, 0)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		t, err := time.ParseInLocation(timeFormat, e.Name(), time.Local)
+		if err != nil {
+			continue
+		}
+		reports = append(reports, timeStampedReport{name: e.Name(), created: t})
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].created.Before(reports[j].created) })
+	return reports, nil
+}
+
+// leftoverReports lists the staging and backup directories in reportsRoot which runs generating a time-stamped
+// report left behind when they were interrupted, dated by the report they were for.
+func leftoverReports(reportsRoot string) ([]timeStampedReport, error) {
+	entries, err := os.ReadDir(reportsRoot)
+	if err != nil {
+		return nil, err
+	}
+	leftovers := make([]timeStampedReport, 0)
+	for _, e := range entries {
+		report, ok := generator.SiblingReportName(e.Name())
+		if !e.IsDir() || !ok {
+			continue
+		}
+		t, err := time.ParseInLocation(timeFormat, report, time.Local)
+		if err != nil {
+			continue
+		}
+		leftovers = append(leftovers, timeStampedReport{name: e.Name(), created: t})
+	}
+	return leftovers, nil
+}
+
+// reportsToRemove returns the reports which fall outside the retention policy. A keepCount or maxAge of 0 disables that rule.
+// The report named current is always retained.
+func reportsToRemove(reports []timeStampedReport, current string, keepCount int, maxAge time.Duration, now time.Time) []string {
+	remove := make([]string, 0)
+	for i, r := range reports {
+		if r.name == current {
+			continue
+		}
+		tooMany := keepCount > 0 && len(reports)-i > keepCount
+		tooOld := maxAge > 0 && now.Sub(r.created) > maxAge
+		if tooMany || tooOld {
+			remove = append(remove, r.name)
+		}
+	}
+	return remove
+}
+
+// leftoversToRemove returns the leftover directories of runs which started before the oldest retained report, or
+// which are older than maxAge. Those of more recent runs may belong to a run still in progress.
+func leftoversToRemove(leftovers []timeStampedReport, oldestRetained time.Time, maxAge time.Duration, now time.Time) []string {
+	remove := make([]string, 0)
+	for _, l := range leftovers {
+		if l.created.Before(oldestRetained) || (maxAge > 0 && now.Sub(l.created) > maxAge) {
+			remove = append(remove, l.name)
+		}
+	}
+	return remove
+}
+
+func cleanupOldReports(reportsRoot, current string, keepCount int, maxAge time.Duration) {
+	if keepCount == 0 && maxAge == 0 {
+		return
+	}
+	reports, err := timeStampedReports(reportsRoot)
+	if err != nil {
+		logger.Debugf("[Warning] Unable to list reports in %s. Reason: %s\n", reportsRoot, err.Error())
+		return
+	}
+	leftovers, err := leftoverReports(reportsRoot)
+	if err != nil {
+		logger.Debugf("[Warning] Unable to list reports in %s. Reason: %s\n", reportsRoot, err.Error())
+		return
+	}
+	now := time.Now()
+	remove := reportsToRemove(reports, current, keepCount, maxAge, now)
+	var oldestRetained time.Time
+	for _, r := range reports {
+		if !slices.Contains(remove, r.name) {
+			oldestRetained = r.created
+			break
+		}
+	}
+	remove = append(remove, leftoversToRemove(leftovers, oldestRetained, maxAge, now)...)
+	for _, name := range remove {
+		p := filepath.Join(reportsRoot, name)
+		if err := os.RemoveAll(p); err != nil {
+			logger.Debugf("[Warning] Unable to remove old report %s. Reason: %s\n", p, err.Error())
+			continue
+		}
+		logger.Debugf("Removed old report %s", p)
+	}
+}
+
+// updateLatestReport points reportsRoot/latest to the given report. The link is replaced with a rename so that readers
+// never observe a missing or half-written pointer. Where symlinks are unavailable a redirect page is written instead.
+func updateLatestReport(reportsRoot, current string) {
+	if runtime.GOOS != "windows" {
+		err := replaceSymlink(current, filepath.Join(reportsRoot, latestReportLink))
+		if err == nil {
+			return
+		}
+		logger.Debugf("[Warning] Unable to update %s symlink. Reason: %s\n", latestReportLink, err.Error())
+	}
+	if err := writeLatestRedirect(reportsRoot, current); err != nil {
+		logger.Debugf("[Warning] Unable to update %s. Reason: %s\n", latestReportRedirect, err.Error())
+	}
+}
+
+func replaceSymlink(target, link string) error {
+	tmp := fmt.Sprintf("%s.%d.tmp", link, os.Getpid())
+	_ = os.Remove(tmp)
+	if err := os.Symlink(target, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func writeLatestRedirect(reportsRoot, current string) error {
+	target := filepath.ToSlash(filepath.Join(current, "index.html"))
+	content := fmt.Sprintf(`<!doctype html><html><head><meta charset="utf-8" /><meta http-equiv="refresh" content="0; url=%[1]s" /></head><body><a href="%[1]s">%[1]s</a></body></html>`, target)
+	dest := filepath.Join(reportsRoot, latestReportRedirect)
+	tmp := fmt.Sprintf("%s.%d.tmp", dest, os.Getpid())
+	if err := os.WriteFile(tmp, []byte(content), common.NewFilePermissions); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	helper "github.com/getgauge/html-report/test_helper"
+)
+
+func newTimeStampedReports(now time.Time, ages ...time.Duration) []timeStampedReport {
+	reports := make([]timeStampedReport, 0)
+	for _, a := range ages {
+		t := now.Add(-a)
+		reports = append(reports, timeStampedReport{name: t.Format(timeFormat), created: t})
+	}
+	return reports
+}
+
+func TestReportsToRemoveKeepsLastN(t *testing.T) {
+	now := time.Now()
+	reports := newTimeStampedReports(now, 4*time.Hour, 3*time.Hour, 2*time.Hour, time.Hour)
+
+	got := reportsToRemove(reports, reports[3].name, 2, 0, now)
+
+	want := []string{reports[0].name, reports[1].name}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestReportsToRemoveOlderThanMaxAge(t *testing.T) {
+	now := time.Now()
+	reports := newTimeStampedReports(now, 72*time.Hour, 25*time.Hour, time.Hour)
+
+	got := reportsToRemove(reports, reports[2].name, 0, 24*time.Hour, now)
+
+	want := []string{reports[0].name, reports[1].name}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestReportsToRemoveNeverRemovesCurrentReport(t *testing.T) {
+	now := time.Now()
+	reports := newTimeStampedReports(now, 72*time.Hour, 48*time.Hour)
+
+	got := reportsToRemove(reports, reports[0].name, 1, time.Hour, now)
+
+	want := []string{reports[1].name}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestCleanupOldReportsIgnoresUnknownDirectories(t *testing.T) {
+	reportsRoot := filepath.Join(os.TempDir(), randomName())
+	defer func(path string) {
+		if err := os.RemoveAll(path); err != nil {
+			t.Errorf("Failed to remove directory %s: %v", path, err)
+		}
+	}(reportsRoot)
+	now := time.Now()
+	old := now.Add(-48 * time.Hour).Format(timeFormat)
+	current := now.Format(timeFormat)
+	for _, d := range []string{old, current, "custom"} {
+		if err := os.MkdirAll(filepath.Join(reportsRoot, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cleanupOldReports(reportsRoot, current, 1, 0)
+
+	if helper.FileExists(filepath.Join(reportsRoot, old)) {
+		t.Errorf("Expected %s to be removed", old)
+	}
+	for _, d := range []string{current, "custom"} {
+		if !helper.FileExists(filepath.Join(reportsRoot, d)) {
+			t.Errorf("Expected %s to be retained", d)
+		}
+	}
+}
+
+func TestCleanupOldReportsRemovesLeftoversOfInterruptedRuns(t *testing.T) {
+	reportsRoot := t.TempDir()
+	now := time.Now()
+	old := now.Add(-48 * time.Hour).Format(timeFormat)
+	previous := now.Add(-time.Hour).Format(timeFormat)
+	running := now.Add(-time.Minute).Format(timeFormat)
+	current := now.Format(timeFormat)
+	for _, d := range []string{previous, current, "." + old + ".staging", "." + old + ".old", "." + running + ".staging"} {
+		if err := os.MkdirAll(filepath.Join(reportsRoot, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cleanupOldReports(reportsRoot, current, 2, 0)
+
+	for _, d := range []string{"." + old + ".staging", "." + old + ".old"} {
+		if helper.FileExists(filepath.Join(reportsRoot, d)) {
+			t.Errorf("Expected %s to be removed", d)
+		}
+	}
+	for _, d := range []string{previous, current, "." + running + ".staging"} {
+		if !helper.FileExists(filepath.Join(reportsRoot, d)) {
+			t.Errorf("Expected %s to be retained", d)
+		}
+	}
+}
+
+func TestUpdateLatestReport(t *testing.T) {
+	reportsRoot := filepath.Join(os.TempDir(), randomName())
+	defer func(path string) {
+		if err := os.RemoveAll(path); err != nil {
+			t.Errorf("Failed to remove directory %s: %v", path, err)
+		}
+	}(reportsRoot)
+	for _, d := range []string{"first", "second"} {
+		if err := os.MkdirAll(filepath.Join(reportsRoot, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	updateLatestReport(reportsRoot, "first")
+	updateLatestReport(reportsRoot, "second")
+
+	if runtime.GOOS == "windows" {
+		if !helper.FileExists(filepath.Join(reportsRoot, latestReportRedirect)) {
+			t.Errorf("Expected %s to be created", latestReportRedirect)
+		}
+		return
+	}
+	target, err := os.Readlink(filepath.Join(reportsRoot, latestReportLink))
+	if err != nil {
+		t.Fatalf("Expected %s to be a symlink. %s", latestReportLink, err.Error())
+	}
+	if target != "second" {
+		t.Errorf("Expected %s to point to second, got %s", latestReportLink, target)
+	}
+}
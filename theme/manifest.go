@@ -0,0 +1,354 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package theme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getgauge/html-report/logger"
+	"github.com/getgauge/html-report/themes"
+)
+
+const (
+	// ManifestFile is the name of the file describing a theme, at the root of the theme directory
+	ManifestFile = "theme.json"
+	// DefaultThemeName refers to the theme shipped with the plugin when used as a parent
+	DefaultThemeName = "default"
+)
+
+// PluginVersion is the version of the plugin, set at build time. Compatibility of themes is not checked if it is empty.
+var PluginVersion = ""
+
+// RequiredTemplates are the templates the report is rendered from. A theme must define them, or inherit them from its parent.
+var RequiredTemplates = []string{"indexPage", "indexPageFailure", "specPage"}
+
+var defineRegex = regexp.MustCompile(`{{-?\s*define\s+"([^"]+)"`)
+
+// Manifest describes a theme
+type Manifest struct {
+	Name          string   `json:"name"`
+	PluginVersion string   `json:"pluginVersion,omitempty"`
+	Parent        string   `json:"parent,omitempty"`
+	Overrides     []string `json:"overrides,omitempty"`
+}
+
+// Theme is a theme directory along with the theme it inherits templates and assets from
+type Theme struct {
+	Path     string
+	Manifest *Manifest
+	Parent   *Theme
+	// FS holds the files of the theme directory, or the embedded default theme if its directory is missing
+	FS fs.FS
+}
+
+// TemplateFile is a template file of a theme, named after its path
+type TemplateFile struct {
+	Name    string
+	Content []byte
+}
+
+// Load reads the theme at the given path and the themes it inherits from, and validates their manifests.
+// A theme without a manifest is a complete theme on its own. defaultPath is where the default theme is installed,
+// which themes inherit from as their default parent; the default theme embedded in the plugin is used if it is missing.
+func Load(path, defaultPath string) (*Theme, error) {
+	return load(path, defaultPath, map[string]bool{})
+}
+
+func load(path, defaultPath string, seen map[string]bool) (*Theme, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if seen[abs] {
+		return nil, fmt.Errorf("theme %s inherits from itself", path)
+	}
+	seen[abs] = true
+	t := &Theme{Path: abs, FS: os.DirFS(abs)}
+	if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+		if !isDefaultThemePath(abs, defaultPath) {
+			return nil, fmt.Errorf("theme directory %s does not exist", path)
+		}
+		logger.Debugf("Theme directory %s not found, using the default theme embedded in the plugin", path)
+		t.FS = themes.Default()
+	}
+	t.Manifest, err = readManifest(t.FS, abs)
+	if err != nil {
+		return nil, err
+	}
+	if t.Manifest != nil && t.Manifest.Parent != "" {
+		t.Parent, err = load(t.parentPath(defaultPath), defaultPath, seen)
+		if err != nil {
+			return nil, fmt.Errorf("parent of theme %s: %s", t.Manifest.Name, err.Error())
+		}
+	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func isDefaultThemePath(abs, defaultPath string) bool {
+	d, err := filepath.Abs(defaultPath)
+	return err == nil && d == abs
+}
+
+func readManifest(fsys fs.FS, dir string) (*Manifest, error) {
+	b, err := fs.ReadFile(fsys, ManifestFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("invalid %s in %s: %s", ManifestFile, dir, err.Error())
+	}
+	return m, nil
+}
+
+func (t *Theme) parentPath(defaultPath string) string {
+	p := t.Manifest.Parent
+	if p == DefaultThemeName {
+		return defaultPath
+	}
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(t.Path, p)
+}
+
+func (t *Theme) validate() error {
+	defined, err := t.definedTemplates()
+	if err != nil {
+		return err
+	}
+	if t.Manifest != nil {
+		m := t.Manifest
+		if m.Name == "" {
+			return fmt.Errorf("%s in %s does not declare a name", ManifestFile, t.Path)
+		}
+		if m.PluginVersion != "" {
+			ok, err := versionMatches(PluginVersion, m.PluginVersion)
+			if err != nil {
+				return fmt.Errorf("theme %s declares an invalid plugin version %q: %s", m.Name, m.PluginVersion, err.Error())
+			}
+			if !ok {
+				return fmt.Errorf("theme %s requires plugin version %s, this is version %s", m.Name, m.PluginVersion, PluginVersion)
+			}
+		}
+		inherited := map[string]bool{}
+		if t.Parent != nil {
+			inherited = t.Parent.Templates()
+		}
+		for _, o := range m.Overrides {
+			if !defined[o] {
+				return fmt.Errorf("theme %s overrides template %s but does not define it", m.Name, o)
+			}
+			if t.Parent != nil && !inherited[o] {
+				return fmt.Errorf("theme %s overrides template %s which its parent does not define", m.Name, o)
+			}
+		}
+		overrides := map[string]bool{}
+		for _, o := range m.Overrides {
+			overrides[o] = true
+		}
+		for name := range defined {
+			if inherited[name] && !overrides[name] {
+				logger.Debugf("[Warning] Theme %s redefines template %s without declaring it in overrides", m.Name, name)
+			}
+		}
+	}
+	all := t.Templates()
+	for _, r := range RequiredTemplates {
+		if !all[r] {
+			return fmt.Errorf("theme at %s does not define the template %s", t.Path, r)
+		}
+	}
+	return nil
+}
+
+// WithOverrides layers the templates and assets of a directory on top of the theme. Unlike a child theme,
+// the directory needs no manifest: its templates replace the ones of the same name and its assets are copied last.
+func (t *Theme) WithOverrides(dir string) (*Theme, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("theme overrides directory %s does not exist", dir)
+	}
+	o := &Theme{Path: abs, Parent: t, FS: os.DirFS(abs)}
+	defined, err := o.definedTemplates()
+	if err != nil {
+		return nil, err
+	}
+	inherited := t.Templates()
+	for name := range defined {
+		if !inherited[name] {
+			logger.Debugf("[Warning] Theme overrides in %s define template %s which the theme does not use", dir, name)
+		}
+	}
+	return o, nil
+}
+
+// Layers returns the directories the theme is made of, starting with the theme it ultimately inherits from.
+// Templates and assets of later layers replace those of earlier ones.
+func (t *Theme) Layers() []string {
+	var paths []string
+	for _, l := range t.layers() {
+		paths = append(paths, l.Path)
+	}
+	return paths
+}
+
+func (t *Theme) layers() []*Theme {
+	if t.Parent == nil {
+		return []*Theme{t}
+	}
+	return append(t.Parent.layers(), t)
+}
+
+// TemplateFiles returns the template files of every layer of the theme, in the order they are to be parsed
+func (t *Theme) TemplateFiles() ([]TemplateFile, error) {
+	var files []TemplateFile
+	for _, l := range t.layers() {
+		f, err := l.ownTemplateFiles()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f...)
+	}
+	return files, nil
+}
+
+func (t *Theme) ownTemplateFiles() ([]TemplateFile, error) {
+	names, err := fs.Glob(t.FS, "views/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	files := make([]TemplateFile, 0, len(names))
+	for _, n := range names {
+		b, err := fs.ReadFile(t.FS, n)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, TemplateFile{Name: filepath.Join(t.Path, filepath.FromSlash(n)), Content: b})
+	}
+	return files, nil
+}
+
+// Templates returns the names of the templates defined by the theme or inherited from its parents
+func (t *Theme) Templates() map[string]bool {
+	names := map[string]bool{}
+	for _, l := range t.layers() {
+		defined, _ := l.definedTemplates()
+		for n := range defined {
+			names[n] = true
+		}
+	}
+	return names
+}
+
+func (t *Theme) definedTemplates() (map[string]bool, error) {
+	files, err := t.ownTemplateFiles()
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, f := range files {
+		for _, m := range defineRegex.FindAllStringSubmatch(string(f.Content), -1) {
+			names[m[1]] = true
+		}
+	}
+	return names, nil
+}
+
+// pluginVersionConstraint is the constraint written to the manifest of new themes, requiring this version of the plugin or later
+func pluginVersionConstraint() string {
+	if PluginVersion == "" {
+		return ""
+	}
+	return ">=" + PluginVersion
+}
+
+// versionMatches checks a version against a constraint made of space separated clauses like >=4.4.0 <5.0.0.
+// An empty version is that of a development build, which matches any constraint.
+func versionMatches(version, constraint string) (bool, error) {
+	clauses := strings.Fields(strings.ReplaceAll(constraint, ",", " "))
+	if len(clauses) == 0 {
+		return false, fmt.Errorf("empty constraint")
+	}
+	v, err := parseVersion(version)
+	if version != "" && err != nil {
+		return false, err
+	}
+	matches := true
+	for _, c := range clauses {
+		op := c[:len(c)-len(strings.TrimLeft(c, "<>="))]
+		want, err := parseVersion(c[len(op):])
+		if err != nil {
+			return false, err
+		}
+		if version == "" {
+			continue
+		}
+		cmp := compareVersions(v, want)
+		switch op {
+		case ">=":
+			matches = matches && cmp >= 0
+		case ">":
+			matches = matches && cmp > 0
+		case "<=":
+			matches = matches && cmp <= 0
+		case "<":
+			matches = matches && cmp < 0
+		case "=", "":
+			matches = matches && cmp == 0
+		default:
+			return false, fmt.Errorf("unknown operator %s", op)
+		}
+	}
+	return matches, nil
+}
+
+func parseVersion(s string) ([3]int, error) {
+	var v [3]int
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return v, fmt.Errorf("invalid version %q", s)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid version %q", s)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,230 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeThemeFile(t *testing.T, dir, name, content string) {
+	p := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newChildTheme(t *testing.T, manifest, templates string) string {
+	dir := t.TempDir()
+	writeThemeFile(t, dir, ManifestFile, manifest)
+	if templates != "" {
+		writeThemeFile(t, dir, filepath.Join("views", "overrides.tmpl"), templates)
+	}
+	return dir
+}
+
+func TestLoadThemeWithoutManifest(t *testing.T) {
+	th, err := Load(defaultThemePath, defaultThemePath)
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got %s", err.Error())
+	}
+	if th.Parent != nil {
+		t.Errorf("Expected default theme to have no parent")
+	}
+}
+
+func TestLoadThemeInheritingFromDefault(t *testing.T) {
+	dir := newChildTheme(t, `{"name": "company", "parent": "default", "overrides": ["bodyFooterTag"]}`, `{{define "bodyFooterTag"}}ACME{{end}}`)
+
+	th, err := Load(dir, defaultThemePath)
+
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got %s", err.Error())
+	}
+	layers := th.Layers()
+	if want, _ := filepath.Abs(defaultThemePath); len(layers) != 2 || layers[0] != want || layers[1] != dir {
+		t.Errorf("Unexpected layers %v", layers)
+	}
+}
+
+func TestLoadThemeWithInvalidManifest(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifest  string
+		templates string
+		err       string
+	}{
+		{"invalid json", `{"name": `, "", "invalid theme.json"},
+		{"missing name", `{"parent": "default"}`, "", "does not declare a name"},
+		{"missing parent", `{"name": "company", "parent": "unknown"}`, "", "does not exist"},
+		{"override not defined", `{"name": "company", "parent": "default", "overrides": ["bodyFooterTag"]}`, "", "does not define it"},
+		{"override unknown to parent", `{"name": "company", "parent": "default", "overrides": ["companyFooter"]}`, `{{define "companyFooter"}}{{end}}`, "parent does not define"},
+		{"missing required templates", `{"name": "company"}`, `{{define "bodyFooterTag"}}{{end}}`, "does not define the template indexPage"},
+		{"incompatible plugin version", `{"name": "company", "parent": "default", "pluginVersion": "<1.0.0"}`, "", "requires plugin version"},
+		{"invalid plugin version", `{"name": "company", "parent": "default", "pluginVersion": ">=four"}`, "", "invalid plugin version"},
+		{"inherits from itself", `{"name": "company", "parent": "."}`, "", "inherits from itself"},
+	}
+	old := PluginVersion
+	PluginVersion = "4.4.6"
+	defer func() { PluginVersion = old }()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Load(newChildTheme(t, test.manifest, test.templates), defaultThemePath)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("Expected error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestVersionMatches(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{"4.4.6", ">=4.4.0 <5.0.0", true},
+		{"5.0.0", ">=4.4.0 <5.0.0", false},
+		{"4.4.6", "4.4.6", true},
+		{"4.4.6", "=4.4", false},
+		{"4.4.6", ">4.4.5, <=4.4.6", true},
+		{"", ">=9.0.0", true},
+	}
+	for _, test := range tests {
+		got, err := versionMatches(test.version, test.constraint)
+		if err != nil {
+			t.Errorf("%s %s: unexpected error %s", test.version, test.constraint, err.Error())
+		}
+		if got != test.want {
+			t.Errorf("%s %s: want %v, got %v", test.version, test.constraint, test.want, got)
+		}
+	}
+}
+
+func TestCopyReportTemplateFilesOverlaysInheritedAssets(t *testing.T) {
+	dir := newChildTheme(t, `{"name": "company", "parent": "default"}`, "")
+	writeThemeFile(t, dir, filepath.Join("assets", "images", "gaugeLogo.png"), "company logo")
+	dest := t.TempDir()
+
+	if err := CopyReportTemplateFiles(dir, dest); err != nil {
+		t.Fatalf("Expected error to be nil, got %s", err.Error())
+	}
+
+	b, _ := os.ReadFile(filepath.Join(dest, "images", "gaugeLogo.png"))
+	if string(b) != "company logo" {
+		t.Errorf("Expected the logo of the theme to replace the inherited one")
+	}
+	if _, err := os.Stat(filepath.Join(dest, "css", "style.css")); err != nil {
+		t.Errorf("Expected inherited assets to be copied: %s", err.Error())
+	}
+}
+
+func TestWithOverridesLayersDirectoryOnTopOfTheme(t *testing.T) {
+	th, err := Load(defaultThemePath, defaultThemePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	writeThemeFile(t, dir, filepath.Join("views", "footer.tmpl"), `{{define "bodyFooterTag"}}ACME{{end}}`)
+	writeThemeFile(t, dir, filepath.Join("assets", "images", "gaugeLogo.png"), "company logo")
+
+	o, err := th.WithOverrides(dir)
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got %s", err.Error())
+	}
+	dest := t.TempDir()
+	if err := o.CopyAssets(dest); err != nil {
+		t.Fatal(err)
+	}
+
+	if layers := o.Layers(); len(layers) != 2 || layers[1] != dir {
+		t.Errorf("Unexpected layers %v", layers)
+	}
+	b, _ := os.ReadFile(filepath.Join(dest, "images", "gaugeLogo.png"))
+	if string(b) != "company logo" {
+		t.Errorf("Expected the overridden logo to be copied")
+	}
+}
+
+func TestWithOverridesFailsForMissingDirectory(t *testing.T) {
+	th, err := Load(defaultThemePath, defaultThemePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := th.WithOverrides(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("Expected an error for a missing overrides directory")
+	}
+}
+
+func TestLoadFallsBackToEmbeddedDefaultTheme(t *testing.T) {
+	missing := GetDefaultThemePath(t.TempDir())
+	dest := t.TempDir()
+
+	th, err := Load(missing, missing)
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got %s", err.Error())
+	}
+	if err := th.CopyAssets(dest); err != nil {
+		t.Fatal(err)
+	}
+
+	if !th.Templates()["indexPage"] {
+		t.Errorf("Expected the templates of the embedded theme to be used")
+	}
+	if _, err := os.Stat(filepath.Join(dest, "css", "style.css")); err != nil {
+		t.Errorf("Expected assets of the embedded theme to be copied: %s", err.Error())
+	}
+}
+
+func TestLoadDoesNotFallBackForMissingCustomTheme(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "company"), defaultThemePath); err == nil {
+		t.Errorf("Expected an error for a missing theme directory")
+	}
+}
+
+func TestTranslationsOfLayersAreMerged(t *testing.T) {
+	dir := newChildTheme(t, `{"name": "company", "parent": "default"}`, "")
+	writeThemeFile(t, dir, filepath.Join(LocalesDir, "de.json"), `{"Success Rate": "Quote"}`)
+	writeThemeFile(t, dir, filepath.Join(LocalesDir, "fr.json"), `{"Success Rate": "Taux de réussite"}`)
+	th, err := Load(dir, defaultThemePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	translations, err := th.Translations()
+
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got %s", err.Error())
+	}
+	if got := translations["de"]["Success Rate"]; got != "Quote" {
+		t.Errorf("Expected the translation of the theme to replace its parent's, got %q", got)
+	}
+	if got := translations["de"]["Total Time"]; got != "Gesamtzeit" {
+		t.Errorf("Expected the other translations of the parent to be kept, got %q", got)
+	}
+	if got := translations["fr"]["Success Rate"]; got != "Taux de réussite" {
+		t.Errorf("Expected a locale of the theme only, got %q", got)
+	}
+}
+
+func TestTranslationsFailForInvalidJSON(t *testing.T) {
+	dir := newChildTheme(t, `{"name": "company", "parent": "default"}`, "")
+	writeThemeFile(t, dir, filepath.Join(LocalesDir, "de.json"), `{"Success Rate": `)
+	th, err := Load(dir, defaultThemePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := th.Translations(); err == nil || !strings.Contains(err.Error(), "de.json") {
+		t.Errorf("Expected an error naming the invalid file, got %v", err)
+	}
+}
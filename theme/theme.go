@@ -6,35 +6,112 @@
 package theme
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 
 	"github.com/getgauge/common"
+	"github.com/getgauge/html-report/env"
 )
 
 const (
-	reportThemeProperty = "GAUGE_HTML_REPORT_THEME_PATH"
+	reportThemeProperty          = "GAUGE_HTML_REPORT_THEME_PATH"
+	reportThemeOverridesProperty = "GAUGE_HTML_REPORT_THEME_OVERRIDES"
 )
 
-var templateBasePath string
-
+// GetDefaultThemePath returns the path of the default theme installed in the plugin directory
 func GetDefaultThemePath(pluginsDir string) string {
-	if templateBasePath == "" {
-		templateBasePath = filepath.Join(pluginsDir, "themes")
-	}
-	return filepath.Join(templateBasePath, "default")
+	return filepath.Join(pluginsDir, "themes", DefaultThemeName)
 }
 
+// PluginDefaultThemePath returns the path of the default theme installed along with the running plugin, whose
+// executable is in the bin directory of the plugin.
+func PluginDefaultThemePath() string {
+	dir, _ := env.GetCurrentExecutableDir()
+	return GetDefaultThemePath(filepath.Dir(dir))
+}
+
+// CopyReportTemplateFiles copies the assets of the theme, and of the themes it inherits from, to the report directory
+//
+// Deprecated: Use Load and Theme.CopyAssets instead.
 func CopyReportTemplateFiles(themePath, reportDir string) error {
-	r := filepath.Join(themePath, "assets")
-	_, err := common.MirrorDir(r, reportDir)
-	return err
+	t, err := Load(themePath, PluginDefaultThemePath())
+	if err != nil {
+		return err
+	}
+	return t.CopyAssets(reportDir)
+}
+
+// CopyAssets copies the assets of every layer of the theme to the report directory, later layers replacing earlier ones
+func (t *Theme) CopyAssets(reportDir string) error {
+	for _, l := range t.layers() {
+		assets, err := fs.Sub(l.FS, "assets")
+		if err != nil {
+			return err
+		}
+		if _, err := fs.Stat(assets, "."); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err := copyFS(assets, reportDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFS(fsys fs.FS, dst string) error {
+	return fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, filepath.FromSlash(p))
+		if d.IsDir() {
+			return os.MkdirAll(target, common.NewDirectoryPermissions)
+		}
+		b, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, b, common.NewFilePermissions)
+	})
+}
+
+// Init copies the default theme at defaultPath to dir as a starting point for a custom theme, named after the
+// directory. It refuses to write into a directory that already has files in it.
+func Init(dir, defaultPath string) error {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("directory %s is not empty", dir)
+	}
+	d, err := Load(defaultPath, defaultPath)
+	if err != nil {
+		return err
+	}
+	if err := copyFS(d.FS, dir); err != nil {
+		return err
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(&Manifest{Name: filepath.Base(abs), PluginVersion: pluginVersionConstraint()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFile), append(b, '\n'), common.NewFilePermissions)
 }
 
 func GetThemePath(pluginsDir string) string {
-	t := os.Getenv(reportThemeProperty)
-	if t == "" {
-		t = GetDefaultThemePath(pluginsDir)
+	d := GetDefaultThemePath(pluginsDir)
+	if t := os.Getenv(reportThemeProperty); t != "" {
+		return t
 	}
-	return t
+	return d
+}
+
+// GetThemeOverridesPath returns the directory whose templates and assets are layered on top of the theme, empty if not set
+func GetThemeOverridesPath() string {
+	return os.Getenv(reportThemeOverridesProperty)
 }
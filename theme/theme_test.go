@@ -16,9 +16,7 @@ import (
 	helper "github.com/getgauge/html-report/test_helper"
 )
 
-func init() {
-	templateBasePath = filepath.Join("..", "themes")
-}
+var defaultThemePath = GetDefaultThemePath("..")
 
 func TestCopyingReportTemplates(t *testing.T) {
 	dirToCopy := filepath.Join(os.TempDir(), randomName())
@@ -28,7 +26,7 @@ func TestCopyingReportTemplates(t *testing.T) {
 		}
 	}(dirToCopy)
 
-	err := CopyReportTemplateFiles(GetThemePath(""), dirToCopy)
+	err := CopyReportTemplateFiles(GetThemePath(".."), dirToCopy)
 	if err != nil {
 		t.Errorf("Expected error == nil, got: %s \n", err.Error())
 	}
@@ -36,7 +34,7 @@ func TestCopyingReportTemplates(t *testing.T) {
 }
 
 func verifyReportTemplateFilesAreCopied(dest string, t *testing.T) {
-	reportDir := filepath.Join(GetThemePath(""), "assets")
+	reportDir := filepath.Join(GetThemePath(".."), "assets")
 	err := filepath.Walk(reportDir, func(path string, info os.FileInfo, err error) error {
 		path = strings.Replace(path, reportDir, "", 1)
 		destFilePath := filepath.Join(dest, path)
@@ -53,3 +51,31 @@ func verifyReportTemplateFilesAreCopied(dest string, t *testing.T) {
 func randomName() string {
 	return fmt.Sprintf("%d", time.Now().UnixNano())
 }
+
+func TestInitCopiesDefaultTheme(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "company")
+
+	if err := Init(dir, defaultThemePath); err != nil {
+		t.Fatalf("Expected error to be nil, got %s", err.Error())
+	}
+
+	th, err := Load(dir, defaultThemePath)
+	if err != nil {
+		t.Fatalf("Expected the new theme to be valid, got %s", err.Error())
+	}
+	if th.Manifest == nil || th.Manifest.Name != "company" || th.Parent != nil {
+		t.Errorf("Expected a standalone theme named company, got %+v", th.Manifest)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "assets", "css", "style.css")); err != nil {
+		t.Errorf("Expected assets to be copied: %s", err.Error())
+	}
+}
+
+func TestInitRefusesNonEmptyDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeThemeFile(t, dir, "notes.txt", "")
+
+	if err := Init(dir, defaultThemePath); err == nil {
+		t.Errorf("Expected an error for a non empty directory")
+	}
+}
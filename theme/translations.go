@@ -0,0 +1,48 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// LocalesDir holds the translations of a theme, in one <locale>.json file per locale mapping
+// the English text of the templates to its translation
+const LocalesDir = "locales"
+
+// Translations returns the messages of every locale the theme or its parents provide, keyed by locale.
+// Messages of later layers replace the ones of the same text, so a theme can change a few of them only.
+func (t *Theme) Translations() (map[string]map[string]string, error) {
+	translations := map[string]map[string]string{}
+	for _, l := range t.layers() {
+		names, err := fs.Glob(l.FS, path.Join(LocalesDir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range names {
+			b, err := fs.ReadFile(l.FS, n)
+			if err != nil {
+				return nil, err
+			}
+			messages := map[string]string{}
+			if err := json.Unmarshal(b, &messages); err != nil {
+				return nil, fmt.Errorf("invalid translations %s in %s: %s", n, l.Path, err.Error())
+			}
+			locale := strings.TrimSuffix(path.Base(n), ".json")
+			if translations[locale] == nil {
+				translations[locale] = map[string]string{}
+			}
+			for k, v := range messages {
+				translations[locale][k] = v
+			}
+		}
+	}
+	return translations, nil
+}
@@ -0,0 +1,46 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+package main
+
+import (
+	"fmt"
+
+	"github.com/getgauge/html-report/generator"
+	"github.com/getgauge/html-report/logger"
+	"github.com/getgauge/html-report/theme"
+)
+
+const themeUsage = `Usage: html-report theme <command> <dir>
+  init  Copies the default theme to <dir> as a starting point for a custom theme.
+  check Renders the theme at <dir> against a sample result and reports missing templates and errors.
+`
+
+// runThemeCommand runs the theme subcommands, which work on a theme directory without running any spec
+func runThemeCommand(args []string) {
+	if len(args) != 2 {
+		fmt.Print(themeUsage)
+		logger.Fatalf("Expected a command and a theme directory, got %v", args)
+	}
+	switch command, dir := args[0], args[1]; command {
+	case "init":
+		if err := theme.Init(dir, theme.PluginDefaultThemePath()); err != nil {
+			logger.Fatalf("Failed to create theme in %s: %s", dir, err.Error())
+		}
+		logger.Infof("Created theme in %s, based on the default theme", dir)
+	case "check":
+		errs := generator.CheckTheme(dir)
+		for _, err := range errs {
+			logger.Warnf("%s", err.Error())
+		}
+		if len(errs) > 0 {
+			logger.Fatalf("Theme %s has %d problem(s)", dir, len(errs))
+		}
+		logger.Infof("Theme %s renders all the pages of the report", dir)
+	default:
+		fmt.Print(themeUsage)
+		logger.Fatalf("Unknown theme command %s", command)
+	}
+}
@@ -41,6 +41,9 @@
 // var loadingImage = 'images/loading.gif';
 // var closeButton = 'images/close.gif';
 
+// The link the lightbox was opened from, focused again when it closes.
+var lightboxOpener = null;
+
 
 
 
@@ -151,13 +154,15 @@ function getKey(e){
 
 	if(key == 'x'){ hideLightbox(); }
 	if(e.keyCode == 27){ hideLightbox(); }
+	// keep the focus on the lightbox while it covers the page
+	if(e.keyCode == 9){ document.getElementById('lightboxLink').focus(); return false; }
 }
 
 
 //
 // listenKey()
 //
-function listenKey () {	document.onkeypress = getKey; }
+function listenKey () {	document.onkeydown = getKey; }
 
 
 //
@@ -174,6 +179,8 @@ function showLightbox(objLink)
 	var objLoadingImage = document.getElementById('loadingImage');
 	var objLightboxDetails = document.getElementById('lightboxDetails');
 
+	// remember the link that opened the lightbox, the focus returns to it on close
+	lightboxOpener = objLink;
 
 	var arrayPageSize = getPageSize();
 	var arrayPageScroll = getPageScroll();
@@ -194,6 +201,8 @@ function showLightbox(objLink)
 
 	imgPreload.onload=function(){
 		objImage.src = objLink.href;
+		var objThumbnail = objLink.getElementsByTagName('img')[0];
+		objImage.setAttribute('alt', (objThumbnail && objThumbnail.getAttribute('alt')) || 'Screenshot');
 
 		// center lightbox and make sure that the top and left values are not negative
 		// and the image placed outside the viewport
@@ -230,6 +239,7 @@ function showLightbox(objLink)
 
 
 		objLightbox.style.display = 'block';
+		document.getElementById('lightboxLink').focus();
 
 		// After image is loaded, update the overlay height as the new image might have
 		// increased the overall page height.
@@ -270,7 +280,10 @@ function hideLightbox()
 	}
 
 	// disable keypress listener
-	document.onkeypress = '';
+	document.onkeydown = '';
+
+	if (lightboxOpener) { lightboxOpener.focus(); }
+	lightboxOpener = null;
 }
 
 
@@ -345,6 +358,7 @@ function initLightbox()
 		var objLoadingImage = document.createElement("img");
 		objLoadingImage.src = loadingImage;
 		objLoadingImage.setAttribute('id','loadingImage');
+		objLoadingImage.setAttribute('alt','Loading');
 		objLoadingImage.style.position = 'absolute';
 		objLoadingImage.style.zIndex = '150';
 		objLoadingImageLink.appendChild(objLoadingImage);
@@ -359,6 +373,9 @@ function initLightbox()
 	// create lightbox div, same note about styles as above
 	var objLightbox = document.createElement("div");
 	objLightbox.setAttribute('id','lightbox');
+	objLightbox.setAttribute('role','dialog');
+	objLightbox.setAttribute('aria-modal','true');
+	objLightbox.setAttribute('aria-label','Screenshot');
 	objLightbox.style.display = 'none';
 	objLightbox.style.position = 'absolute';
 	objLightbox.style.zIndex = '100';
@@ -367,6 +384,7 @@ function initLightbox()
 	// create link
 	var objLink = document.createElement("a");
 	objLink.setAttribute('href','#');
+	objLink.setAttribute('id','lightboxLink');
 	objLink.setAttribute('title','Click to close');
 	objLink.onclick = function () {hideLightbox(); return false;}
 	objLightbox.appendChild(objLink);
@@ -380,6 +398,7 @@ function initLightbox()
 		var objCloseButton = document.createElement("img");
 		objCloseButton.src = closeButton;
 		objCloseButton.setAttribute('id','closeButton');
+		objCloseButton.setAttribute('alt','Close');
 		objCloseButton.style.position = 'absolute';
 		objCloseButton.style.zIndex = '200';
 		objLink.appendChild(objCloseButton);
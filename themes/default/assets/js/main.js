@@ -39,10 +39,26 @@ function filterSpecList(status) {
             $(this).hide();
         }
     });
-    var specs = $(".spec-list a").filter(function () { return $(this).children().first().is(':visible'); })
+    updateSpecDirs(true);
+    var specs = $(".spec-list a").filter(function () { return isSpecShown($(this).parent()); })
     filterSidebar(specs, $('#searchSpecifications').val().trim());
 }
 
+// The specs of a collapsed folder are not visible, but still shown as far as the filters are concerned.
+function isSpecShown(spec) {
+    return $(spec).css('display') !== 'none';
+}
+
+// updateSpecDirs hides the folders of the sidebar without any spec left by the filters, and expands the others
+// while filtering so that the specs found can be seen.
+function updateSpecDirs(filtering) {
+    $('#listOfSpecifications details.spec-dir').each(function () {
+        var shown = $(this).find('li.spec-name').filter(function () { return isSpecShown(this); }).length > 0;
+        $(this).toggle(shown);
+        if (filtering && shown) this.open = true;
+    });
+}
+
 function updateQueryParamsForSpecsUrl(element) {
     if (!element || isSessionStorageAccessible()) {
         return;
@@ -53,9 +69,9 @@ function updateQueryParamsForSpecsUrl(element) {
 }
 
 function showFirstSpecContent() {
-    let elem = $('li.spec-name:visible:first');
-    updateQueryParamsForSpecsUrl(elem.parent()[0])
-    elem.click();
+    let link = $('li.spec-name:visible:first a')[0];
+    updateQueryParamsForSpecsUrl(link)
+    if (link) link.click();
     if ($('li.spec-name:visible:first').length === 0) {
         $('#specificationContainer').hide();
     }
@@ -64,6 +80,7 @@ function showFirstSpecContent() {
 function filterSidebar(specsCollection, searchText) {
     if (!index) return;
     tagMatches = index.Tags[searchText];
+    ownerMatches = index.Owners && index.Owners[searchText];
     specsCollection.each(function () {
         let elem = $(this);
         updateQueryParamsForSpecsUrl(elem[0])
@@ -78,72 +95,151 @@ function filterSidebar(specsCollection, searchText) {
             });
             return arr.length > 0;
         }
-        specHeadingText = elem.text().trim().toLowerCase();
-        if (existsIn(tagMatches) || specHeadingText.indexOf(searchText.toLowerCase()) > -1 || searchText === '') {
-            $(elem.find('li')[0]).show();
+        specHeadingText = elem.find('.scenarioname').text().trim().toLowerCase();
+        if (existsIn(tagMatches) || existsIn(ownerMatches) || specHeadingText.indexOf(searchText.toLowerCase()) > -1 || searchText === '') {
+            elem.parent().show();
         } else {
-            $(elem.find('li')[0]).hide();
+            elem.parent().hide();
         }
     })
+    updateSpecDirs(searchText !== '' || !!dataStore.get('FilterStatus'));
 }
 
 function resetSidebar() {
     $('#listOfSpecifications li.spec-name').each(function () {
         $(this).show();
     });
+    updateSpecDirs(false);
 }
 
+var modalOpener = null;
+
 function openModal(e) {
-    $(this).next('.modal').css('display', 'block');
+    modalOpener = this;
+    $(this).next('.modal').css('display', 'block').find('.close').focus();
     $('body').addClass('is-modal-open');
 }
 
 function closeModal() {
+    if (!$('body').hasClass('is-modal-open')) return;
     $('.modal').css('display', 'none');
     $('body').removeClass('is-modal-open');
+    if (modalOpener) modalOpener.focus();
+    modalOpener = null;
+}
+
+// Keeps the focus within the open modal, as the page behind it is covered.
+function trapModalFocus(e) {
+    var focusable = $('.modal:visible').find('button, [tabindex="0"]');
+    if (focusable.length === 0) return;
+    var index = focusable.index(document.activeElement);
+    var next = e.shiftKey ? index - 1 : index + 1;
+    e.preventDefault();
+    focusable[(next + focusable.length) % focusable.length].focus();
 }
 
 function isDescendingOrder(specsSortOrder) {
     return specsSortOrder === SORTING_ORDER.DESC;
 }
 
-function createDate(hours, minutes, seconds) {
-    var date = new Date();
-    date.setHours(hours);
-    date.setMinutes(minutes);
-    date.setSeconds(seconds);
-    return date;
+// sortSpecLists sorts the specs of each list of the sidebar, the specs of a folder staying in it.
+function sortSpecLists(compare) {
+    $('#listOfSpecifications ul.spec-list').each(function () {
+        $(this).children('li').sort(compare).appendTo(this);
+    });
 }
 
 function sortSpecsByName(sortingOrder) {
-    return $('#listOfSpecifications ul#scenarios a').sort(function (leftSpec, rightSpec) {
+    sortSpecLists(function (leftSpec, rightSpec) {
         if (isDescendingOrder(sortingOrder))
-            return $(leftSpec).find('.spec-name span:nth-child(1)').text() > $(rightSpec).find('.spec-name span:nth-child(1)').text() ? -1 : 1;
+            return $(leftSpec).find('.scenarioname').text() > $(rightSpec).find('.scenarioname').text() ? -1 : 1;
         else
-            return $(leftSpec).find('.spec-name span:nth-child(1)').text() < $(rightSpec).find('.spec-name span:nth-child(1)').text() ? -1 : 1;
+            return $(leftSpec).find('.scenarioname').text() < $(rightSpec).find('.scenarioname').text() ? -1 : 1;
     });
 }
 
 function sortSpecsByExecutionTime(sortingOrder) {
-    return $('#listOfSpecifications ul#scenarios a').sort(function (leftSpec, rightSpec) {
-        var leftSpecTime = $(leftSpec).find('.spec-name span:nth-child(2)').text().split(':');
-        var rightSpecTime = $(rightSpec).find('.spec-name span:nth-child(2)').text().split(':');
-        var leftDate = createDate.apply(null, leftSpecTime.map(time => parseInt(time)));
-        var rightDate = createDate.apply(null, rightSpecTime.map(time => parseInt(time)));
+    sortSpecLists(function (leftSpec, rightSpec) {
+        var leftSpecTime = parseInt($(leftSpec).find('.time').attr('data-execution-time'));
+        var rightSpecTime = parseInt($(rightSpec).find('.time').attr('data-execution-time'));
 
         if (isDescendingOrder(sortingOrder))
-            return leftDate > rightDate ? -1 : 1;
+            return leftSpecTime > rightSpecTime ? -1 : 1;
         else
-            return leftDate < rightDate ? -1 : 1;
+            return leftSpecTime < rightSpecTime ? -1 : 1;
+    });
+}
+
+// compareByOrder compares the sort keys of two specs or scenarios in one of the orders of the report, ties
+// keeping the order of execution.
+function compareByOrder(order, left, right) {
+    var result = 0;
+    switch (order) {
+        case 'status': result = left.status - right.status; break;
+        case 'source': result = left.source - right.source; break;
+        case 'name': result = left.name.localeCompare(right.name); break;
+        case 'duration': result = right.duration - left.duration; break;
+    }
+    return result || left.execution - right.execution;
+}
+
+function statusRank(element) {
+    var e = $(element);
+    if (e.hasClass('known') || e.find('.scenario-known-issue').length > 0) return 1;
+    if (e.hasClass('failed')) return 0;
+    if (e.hasClass('skipped')) return 2;
+    return 3;
+}
+
+function specOrderKeys(spec) {
+    var link = $(spec).find('a');
+    return {
+        status: statusRank(spec),
+        source: parseInt(link.attr('data-source-index')),
+        execution: parseInt(link.attr('data-execution-index')),
+        name: $(spec).find('.scenarioname').text(),
+        duration: parseInt($(spec).find('.time').attr('data-execution-time'))
+    };
+}
+
+function scenarioOrderKeys(scenario) {
+    var head = $(scenario).find('.scenario-head').first();
+    return {
+        status: statusRank(scenario),
+        source: parseInt(head.attr('data-source-line')),
+        execution: parseInt(head.attr('data-execution-index')),
+        name: head.find('.head').text().trim(),
+        duration: parseInt(head.find('.time').attr('data-execution-time'))
+    };
+}
+
+function orderSpecs(order) {
+    sortSpecLists(function (left, right) {
+        return compareByOrder(order, specOrderKeys(left), specOrderKeys(right));
     });
 }
 
+function orderScenarios(order) {
+    var content = $('#specItemsContainer .content');
+    content.children('.scenario-container').sort(function (left, right) {
+        return compareByOrder(order, scenarioOrderKeys(left), scenarioOrderKeys(right));
+    }).appendTo(content);
+    // The tables of table driven scenarios follow the scenarios they belong to.
+    if (scenarioVisibilityManager) scenarioVisibilityManager.updateVisibility();
+}
+
 function toggleSortIcons(element, sortingOrder) {
     $(element).find('.sort-icons .fa').removeClass('active');
     if (isDescendingOrder(sortingOrder))
         $(element).find('.sort-icons .fa-caret-up').addClass('active');
     else
         $(element).find('.sort-icons .fa-caret-down').addClass('active');
+    var label = $(element).attr('aria-label').replace(/, (ascending|descending)$/, '');
+    $(element).attr('aria-label', label + (isDescendingOrder(sortingOrder) ? ', descending' : ', ascending'));
+}
+
+function setFilterActive(element) {
+    $(element).addClass('active').attr('aria-pressed', 'true');
 }
 
 const DATA_ATTRS = {
@@ -358,20 +454,68 @@ class ScenarioVisibilityManager {
     }
 }
 
+const COLOR_SCHEME_KEY = 'gauge-html-report-color-scheme';
+
+var scenarioVisibilityManager = null;
+
 var initializers = {
+    "initializeColorScheme": function () {
+        // The choice outlives the session, unlike the filters, so it is kept in localStorage.
+        var select = $('#color-scheme');
+        select.val(document.documentElement.getAttribute('data-color-scheme'));
+        select.change(function () {
+            var scheme = $(this).val();
+            document.documentElement.setAttribute('data-color-scheme', scheme);
+            try {
+                localStorage.setItem(COLOR_SCHEME_KEY, scheme);
+            } catch (e) {
+                return;
+            }
+        });
+    },
+    "localizeTimestamps": function () {
+        // Timestamps are shown in the time zone of the reader, the one the report was generated for stays in the tooltip.
+        // Those of a report generated for a configured time zone are left in it.
+        $('time[datetime]').not('[data-timezone]').each(function () {
+            var self = $(this), date = new Date(self.attr('datetime'));
+            if (isNaN(date.getTime())) return;
+            var local;
+            try {
+                local = date.toLocaleString(document.documentElement.lang || undefined, {
+                    year: 'numeric', month: 'short', day: 'numeric', hour: 'numeric', minute: '2-digit', timeZoneName: 'short'
+                });
+            } catch (e) {
+                return;
+            }
+            self.attr('title', self.text().trim()).text(local);
+        });
+    },
+    "registerKeyboardActivation": function () {
+        // Elements acting as buttons are activated with Enter and Space like native buttons.
+        $(document).on('keydown', '[role="button"], .row-selector', function (e) {
+            if (e.target !== this || (e.key !== 'Enter' && e.key !== ' ')) return;
+            e.preventDefault();
+            this.click();
+        });
+    },
     "initializeFilters": function () {
+        // Pages like the tags page link to the index page with the search to apply, e.g. index.html#search=smoke.
+        var hashSearch = new URLSearchParams(location.hash.slice(1)).get('search');
+        if (hashSearch) {
+            dataStore.insertItem('SearchText', hashSearch.trim());
+        }
         if (dataStore.get('FilterStatus')) {
             filterSpecList(dataStore.get('FilterStatus'));
             $('.spec-filter').each(function () {
                 if ($(this).data('status') === dataStore.get('FilterStatus')) {
-                    $(this).addClass('active');
+                    setFilterActive(this);
                 }
             });
             if ($('li.spec-name:visible:first').length === 0) {
                 $('#specificationContainer').hide();
             }
         } else {
-            $('.total-specs').addClass('active');
+            setFilterActive('.total-specs');
         }
         if (dataStore.get('SearchText')) {
             $('#searchSpecifications').val(dataStore.get('SearchText'));
@@ -381,13 +525,13 @@ var initializers = {
     },
     "attachScenarioToggle": function () {
         var tableState = new TableSelectionState();
-        var scenarioVisibilityManager = new ScenarioVisibilityManager(tableState);
+        scenarioVisibilityManager = new ScenarioVisibilityManager(tableState);
         scenarioVisibilityManager.initialize();
     },
     "attachSpecFilter": function () {
         var resetState = function () {
             $('.spec-filter, .total-specs').each(function () {
-                $(this).removeClass('active');
+                $(this).removeClass('active').attr('aria-pressed', 'false');
             });
         };
         $('.spec-filter, #pie-chart path.shadow').click(function () {
@@ -397,21 +541,23 @@ var initializers = {
             filterSpecList(status);
             showFirstSpecContent();
             $(this).addClass('active');
+            $('.spec-filter[data-status="' + status + '"]').attr('aria-pressed', 'true');
         });
         $('.total-specs').click(function () {
             resetState();
             resetSidebar();
             dataStore.removeItem('FilterStatus');
-            var specs = $(".spec-list a").filter(function () { return $(this).children().first().is(':visible'); })
+            var specs = $(".spec-list a").filter(function () { return isSpecShown($(this).parent()); })
             filterSidebar(specs, $('#searchSpecifications').val().trim());
             showFirstSpecContent();
-            $(this).addClass('active');
+            setFilterActive(this);
         });
     },
     "registerModals": function () {
         $('.modal-link').click(openModal);
         $(document).keydown(function (e) {
             if (e.keyCode == 27) closeModal();
+            if (e.key === 'Tab' && $('body').hasClass('is-modal-open')) trapModalFocus(e);
         })
         $('.close').click(closeModal)
     },
@@ -420,7 +566,8 @@ var initializers = {
             var conceptSteps = $(this).next('.concept-steps');
             var iconClass = $(conceptSteps).is(':visible') ? "plus" : "minus";
             $(conceptSteps).fadeToggle('fast', 'linear');
-            $(this).find("i.fa").removeClass("fa-minus-square").removeClass("fa-plus-square").addClass("fa-" + iconClass + "-square");
+            $(this).find("i.fa").removeClass("fa-minus-square").removeClass("fa-plus-square").addClass("fa-" + iconClass + "-square")
+                .attr('aria-expanded', iconClass === "minus");
         });
     },
     "registerMessageToggle": function () {
@@ -428,14 +575,16 @@ var initializers = {
             var messages = $(this).next('.messages');
             var iconClass = messages.is(':visible') ? "plus" : "minus";
             messages.fadeToggle('fast', 'linear');
-            $(this).removeClass("fa-minus-square").removeClass("fa-plus-square").addClass("fa-" + iconClass + "-square");
+            $(this).removeClass("fa-minus-square").removeClass("fa-plus-square").addClass("fa-" + iconClass + "-square")
+                .attr('aria-expanded', iconClass === "minus");
         });
     },
     "registerErrorContainerToggle": function () {
         $(".error-container .toggle-show").click(function () {
             var self = $(this);
             self.next('.exception-container').stop().toggleClass('hidden');
-            self.text(self.text().indexOf("Show") > 0 ? "[Hide details]" : "[Show details]");
+            var show = self.attr('aria-expanded') !== 'true';
+            self.text(self.data(show ? 'hide-label' : 'show-label')).attr('aria-expanded', show);
         });
     },
     "registerSearch": function () {
@@ -460,6 +609,7 @@ var initializers = {
                 term = term.toLowerCase();
                 var tagChoices = Object.keys(index.Tags);
                 var specChoices = Object.keys(index.Specs);
+                var ownerChoices = Object.keys(index.Owners || {});
                 var suggestions = [];
                 var suggestionPredicate = function (type) {
                     return function (x) {
@@ -469,10 +619,11 @@ var initializers = {
                 };
                 tagChoices.forEach(suggestionPredicate("tag"));
                 specChoices.forEach(suggestionPredicate("spec"));
+                ownerChoices.forEach(suggestionPredicate("owner"));
                 suggest(suggestions);
             },
             renderItem: function (item, search) {
-                iconClass = item[1] == "tag" ? "tags" : "bars"
+                iconClass = { tag: "tags", owner: "users" }[item[1]] || "bars"
                 return '<div class="autocomplete-suggestion" data-value="' + item[0] + '"><i class="fa fa-' + iconClass + '" aria-hidden="true"></i>&nbsp;' + item[0] + '</div>';
             },
             onSelect: function (e, term, item) {
@@ -508,8 +659,62 @@ var initializers = {
             var sortingFunc;
             sortingFunc = sortBy === 'specs-name' ? sortSpecsByName : sortSpecsByExecutionTime;
             toggleSortIcons(this, sortingOrder);
-            $('#listOfSpecifications ul#scenarios').html(sortingFunc(sortingOrder));
+            sortingFunc(sortingOrder);
         });
+    },
+    "initializeSortOrders": function () {
+        // An order chosen on one page is kept on the others, e.g. scenarios in source order on every spec page.
+        $('.sort-order select').each(function () {
+            var select = $(this), of = select.data('order-of'), key = of + '-order';
+            var apply = function () {
+                if (of === 'specs') {
+                    orderSpecs(select.val());
+                } else {
+                    orderScenarios(select.val());
+                }
+            };
+            var stored = dataStore.get(key);
+            if (stored && stored !== select.val() && select.find('option[value="' + stored + '"]').length > 0) {
+                select.val(stored);
+                apply();
+            }
+            select.change(function () {
+                dataStore.insertItem(key, select.val());
+                apply();
+            });
+        });
+    },
+    "initializeScenariosView": function () {
+        // The scenarios page lists the scenarios of js/scenarios.js, e.g. scenarios.html#status=fail&search=smoke.
+        var rows = $('#scenario-rows');
+        if (rows.length === 0 || typeof scenarios === 'undefined') return;
+        var search = $('#scenario-search'), status = $('#scenario-status'), count = $('#scenario-count');
+        var statusNames = {};
+        status.find('option').each(function () { statusNames[$(this).val()] = $(this).text(); });
+        var hash = new URLSearchParams(location.hash.slice(1));
+        search.val(hash.get('search') || '');
+        if (hash.get('status') in statusNames) status.val(hash.get('status'));
+        var render = function () {
+            var text = search.val().trim().toLowerCase(), wanted = status.val();
+            var body = document.createDocumentFragment(), shown = 0;
+            $.each(scenarios, function (i, s) {
+                if (wanted && s.Status !== wanted) return;
+                if (text && [s.Name, s.Spec].concat(s.Tags, s.Owners || []).join('\n').toLowerCase().indexOf(text) === -1) return;
+                shown++;
+                var row = $('<tr>');
+                row.append($('<td>').append($('<a>').attr('href', s.ReportFile).text(s.Name)));
+                row.append($('<td>').text(s.Spec));
+                row.append($('<td>').text(s.Tags.join(', ')));
+                row.append($('<td>').addClass('timing-status ' + s.Status).text(statusNames[s.Status] || s.Status));
+                row.append($('<td>').addClass('timing-time').attr('data-duration', s.Duration).text(s.Time));
+                body.appendChild(row[0]);
+            });
+            rows.empty().append(body);
+            count.text(count.data('format').replace('{shown}', shown).replace('{total}', scenarios.length));
+        };
+        search.on('input', render);
+        status.change(render);
+        render();
     }
 };
 
@@ -570,6 +775,7 @@ function initMultilineSections() {
 
             // Toggle content
             content.classList.toggle('expanded', !isExpanded);
+            this.setAttribute('aria-expanded', String(!isExpanded));
 
             // Toggle icon between plus and minus
             if (isExpanded) {
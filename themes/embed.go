@@ -0,0 +1,26 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+// Package themes embeds the default theme into the plugin, so that reports can be generated
+// even when the themes directory shipped with the plugin can't be found.
+package themes
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed default
+var themes embed.FS
+
+// Default returns the files of the default theme, rooted at the theme directory
+func Default() fs.FS {
+	d, err := fs.Sub(themes, "default")
+	if err != nil {
+		panic(err)
+	}
+	return d
+}